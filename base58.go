@@ -0,0 +1,188 @@
+package xuid
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/google/uuid"
+)
+
+// base58Index maps each byte to its base58 digit, or to -1 if the byte is
+// not in Base58Alphabet.
+var base58Index = func() [256]int8 {
+	var t [256]int8
+	for i := range t {
+		t[i] = -1
+	}
+	for i := 0; i < len(Base58Alphabet); i++ {
+		t[Base58Alphabet[i]] = int8(i)
+	}
+	return t
+}()
+
+// base58Chunk is the number of digits decoded at once: 58^10 is the
+// largest power of 58 that fits in a uint64.
+const base58Chunk = 10
+
+// pow58 holds the powers of 58 up to 58^base58Chunk.
+var pow58 = func() [base58Chunk + 1]uint64 {
+	var t [base58Chunk + 1]uint64
+	t[0] = 1
+	for i := 1; i < len(t); i++ {
+		t[i] = t[i-1] * 58
+	}
+	return t
+}()
+
+// decodeBase58 decodes s, whose characters must all be in Base58Alphabet,
+// into a UUID. Like base58.Decode, every leading "1" stands for a zero
+// byte. It reports false if s does not encode exactly 16 bytes.
+//
+// The payload is accumulated as a 128-bit integer, ten digits at a time,
+// instead of with the byte-wise big-integer arithmetic of base58.Decode,
+// which makes Parse several times faster and allocation free.
+func decodeBase58(s string) (uuid.UUID, bool) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == Base58Alphabet[0] {
+		zeros++
+	}
+	var hi, lo uint64
+	for rest := s[zeros:]; rest != ""; {
+		n := min(len(rest), base58Chunk)
+		var chunk uint64
+		for i := 0; i < n; i++ {
+			chunk = chunk*58 + uint64(base58Index[rest[i]])
+		}
+		rest = rest[n:]
+
+		// (hi, lo) = (hi, lo) * 58^n + chunk, failing on overflow.
+		carry, hiProduct := bits.Mul64(hi, pow58[n])
+		if carry != 0 {
+			return uuid.Nil, false
+		}
+		loCarry, loProduct := bits.Mul64(lo, pow58[n])
+		var c uint64
+		lo, c = bits.Add64(loProduct, chunk, 0)
+		hi, c = bits.Add64(hiProduct, loCarry, c)
+		if c != 0 {
+			return uuid.Nil, false
+		}
+	}
+	n := 8 - bits.LeadingZeros64(lo)/8
+	if hi != 0 {
+		n = 16 - bits.LeadingZeros64(hi)/8
+	}
+	if zeros+n != 16 {
+		return uuid.Nil, false
+	}
+	var id uuid.UUID
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, true
+}
+
+// base58Pairs maps each number below 58^2 to its two base58 digits.
+var base58Pairs = func() [58 * 58][2]byte {
+	var t [58 * 58][2]byte
+	for i := range t {
+		t[i] = [2]byte{Base58Alphabet[i/58], Base58Alphabet[i%58]}
+	}
+	return t
+}()
+
+// base58Limb is the divisor of each round of encodeBase58: 58^5 is the
+// largest power of 58 below 2^32, so a remainder shifted by 32 bits and
+// the next 32-bit limb fit in a uint64.
+const base58Limb = 58 * 58 * 58 * 58 * 58
+
+// base58Digits holds the encoding of a UUID padded with leading "1"s to
+// five rounds of five digits, more than the EncodedLenMax digits needed.
+type base58Digits [25]byte
+
+// base58Limbs holds a UUID as four 32-bit limbs, most significant first.
+type base58Limbs struct{ u0, u1, u2, u3 uint64 }
+
+func newBase58Limbs(id *uuid.UUID) base58Limbs {
+	return base58Limbs{
+		uint64(binary.BigEndian.Uint32(id[0:])),
+		uint64(binary.BigEndian.Uint32(id[4:])),
+		uint64(binary.BigEndian.Uint32(id[8:])),
+		uint64(binary.BigEndian.Uint32(id[12:])),
+	}
+}
+
+// div returns the quotient and remainder of l by 58^5. The divisor is a
+// constant, so the compiler replaces the divisions by multiplications,
+// which are much faster than the 128-bit division of bits.Div64.
+func (l base58Limbs) div() (base58Limbs, uint32) {
+	q0 := l.u0 / base58Limb
+	c := (l.u0-q0*base58Limb)<<32 | l.u1
+	q1 := c / base58Limb
+	c = (c-q1*base58Limb)<<32 | l.u2
+	q2 := c / base58Limb
+	c = (c-q2*base58Limb)<<32 | l.u3
+	q3 := c / base58Limb
+	return base58Limbs{q0, q1, q2, q3}, uint32(c - q3*base58Limb)
+}
+
+// put writes the five digits of r, below 58^5, at d[k:k+5].
+func (d *base58Digits) put(k int, r uint32) {
+	lo := r % (58 * 58)
+	r /= 58 * 58
+	mid := r % (58 * 58)
+	d[k] = Base58Alphabet[r/(58*58)]
+	d[k+1], d[k+2] = base58Pairs[mid][0], base58Pairs[mid][1]
+	d[k+3], d[k+4] = base58Pairs[lo][0], base58Pairs[lo][1]
+}
+
+// encodeBase58 writes the digits of id to d.
+func encodeBase58(d *base58Digits, id *uuid.UUID) {
+	l := newBase58Limbs(id)
+	var r uint32
+	for k := len(d) - 5; k >= 0; k -= 5 {
+		l, r = l.div()
+		d.put(k, r)
+	}
+}
+
+// encodeBase58x2 is encodeBase58 for two UUIDs at once. Interleaving the
+// rounds lets the processor overlap the two chains of dependent
+// multiplications, which makes bulk encoding faster than encoding one UUID
+// at a time.
+func encodeBase58x2(d1, d2 *base58Digits, id1, id2 *uuid.UUID) {
+	l1, l2 := newBase58Limbs(id1), newBase58Limbs(id2)
+	var r1, r2 uint32
+	for k := len(d1) - 5; k >= 0; k -= 5 {
+		l1, r1 = l1.div()
+		l2, r2 = l2.div()
+		d1.put(k, r1)
+		d2.put(k, r2)
+	}
+}
+
+// appendDigits appends the digits of id in d to dst, with the same output
+// as base58.Encode: every leading zero byte of id is encoded as "1".
+func appendDigits(dst []byte, d *base58Digits, id *uuid.UUID) []byte {
+	zeros := 0
+	for zeros < len(id) && id[zeros] == 0 {
+		zeros++
+	}
+	for i := 0; i < zeros; i++ {
+		dst = append(dst, Base58Alphabet[0])
+	}
+	// The padding is not part of the encoding.
+	start := 0
+	for start < len(d) && d[start] == Base58Alphabet[0] {
+		start++
+	}
+	return append(dst, d[start:]...)
+}
+
+// appendBase58 appends the base58 encoding of id to dst, with the same
+// output as base58.Encode. The UUID is read as four 32-bit limbs, divided
+// by 58^5 in each round, so each round yields five digits.
+func appendBase58(dst []byte, id uuid.UUID) []byte {
+	var d base58Digits
+	encodeBase58(&d, &id)
+	return appendDigits(dst, &d, &id)
+}
@@ -0,0 +1,78 @@
+package xuid_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/btcsuite/btcd/btcutil/base58"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseMatchesBase58 checks that Parse accepts exactly the payloads that
+// base58.Decode decodes to 16 bytes, and decodes them to the same UUID.
+func TestParseMatchesBase58(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	payloads := []string{
+		strings.Repeat("1", 16),
+		strings.Repeat("1", 17),
+		strings.Repeat("1", 15),
+		strings.Repeat("z", 22),
+		strings.Repeat("z", 23),
+		"1" + base58.Encode(uuid.Max[:]),
+		base58.Encode(uuid.Max[:]),
+	}
+	for i := 0; i < 2000; i++ {
+		var id uuid.UUID
+		rng.Read(id[:])
+		// Zero leading bytes to cover payloads with leading "1"s.
+		for j := 0; j < rng.Intn(4); j++ {
+			id[j] = 0
+		}
+		payloads = append(payloads, base58.Encode(id[:]))
+	}
+	for i := 0; i < 2000; i++ {
+		b := make([]byte, 1+rng.Intn(25))
+		for j := range b {
+			b[j] = xuid.Base58Alphabet[rng.Intn(len(xuid.Base58Alphabet))]
+		}
+		payloads = append(payloads, string(b))
+	}
+
+	for _, p := range payloads {
+		want := base58.Decode(p)
+
+		x, err := xuid.Parse("user_" + p)
+
+		if len(want) != 16 {
+			assert.ErrorIs(t, err, xuid.ErrParse, p)
+			continue
+		}
+		if assert.NoError(t, err, p) {
+			assert.Equal(t, uuid.UUID(want), x.GetUUID(), p)
+		}
+	}
+}
+
+// TestStringMatchesBase58 checks that String encodes payloads exactly as
+// base58.Encode.
+func TestStringMatchesBase58(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ids := []uuid.UUID{uuid.Nil, uuid.Max}
+	for i := 0; i < 2000; i++ {
+		var id uuid.UUID
+		rng.Read(id[:])
+		for j := 0; j < rng.Intn(4); j++ {
+			id[j] = 0
+		}
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
+		x, _ := xuid.NewWith(id, "")
+
+		assert.Equal(t, base58.Encode(id[:]), x.String(), id)
+	}
+}
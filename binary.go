@@ -0,0 +1,14 @@
+package xuid
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// It returns the string form, so values written by clients that check for
+// BinaryMarshaler, such as go-redis and encoding/gob, keep the prefix and
+// stay readable.
+func (x XUID) MarshalBinary() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (x *XUID) UnmarshalBinary(data []byte) error {
+	return ParseInto(x, string(data))
+}
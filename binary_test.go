@@ -0,0 +1,55 @@
+package xuid_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryMarshaling(t *testing.T) {
+	t.Run("marshals XUID to its string form", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+
+		data, err := id.MarshalBinary()
+
+		require.NoError(t, err)
+		assert.Equal(t, id.String(), string(data))
+	})
+
+	t.Run("unmarshals string form", func(t *testing.T) {
+		original := xuid.MustNewSortable("user")
+
+		var parsed xuid.XUID
+		err := parsed.UnmarshalBinary([]byte(original.String()))
+
+		require.NoError(t, err)
+		assert.True(t, original.Equal(parsed))
+	})
+
+	t.Run("returns error for invalid data", func(t *testing.T) {
+		var parsed xuid.XUID
+
+		assert.ErrorIs(t, parsed.UnmarshalBinary([]byte("invalid_0OIl")), xuid.ErrParse)
+	})
+
+	t.Run("implements interfaces checked by go-redis", func(t *testing.T) {
+		var _ encoding.BinaryMarshaler = xuid.XUID{}
+		var _ encoding.BinaryUnmarshaler = &xuid.XUID{}
+	})
+
+	t.Run("round-trips through gob", func(t *testing.T) {
+		original := xuid.MustNewSortable("user")
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(original))
+
+		var decoded xuid.XUID
+		require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+
+		assert.True(t, original.Equal(decoded))
+	})
+}
@@ -0,0 +1,39 @@
+package xuid
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/bits"
+)
+
+// Bucket deterministically assigns x to one of n buckets, in [0, n).
+// Assignments are uniform and depend on salt, so using a different salt per
+// experiment or rollout yields independent cohorts. Only the UUID takes part
+// in the assignment; the prefix is ignored.
+//
+// Every service computing Bucket with the same n and salt assigns an ID to
+// the same bucket. Bucket panics if n <= 0.
+func (x XUID) Bucket(n int, salt []byte) int {
+	if n <= 0 {
+		panic("xuid: invalid argument to Bucket")
+	}
+	hi, _ := bits.Mul64(x.saltedHash(salt), uint64(n))
+	return int(hi)
+}
+
+// saltedHash returns the first 8 bytes of SHA-256(salt || UUID).
+func (x XUID) saltedHash(salt []byte) uint64 {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write(x.uuid[:])
+	var sum [sha256.Size]byte
+	return binary.BigEndian.Uint64(h.Sum(sum[:0]))
+}
+
+// RolloutPercent maps x to a stable value in [0, 100) for gradual rollouts:
+// an ID is in a rollout at p percent when RolloutPercent(salt) < p, so
+// raising p only ever adds IDs. Like Bucket, the value depends on salt and
+// the UUID only, and is the same in every service using the same salt.
+func (x XUID) RolloutPercent(salt string) float64 {
+	return float64(x.saltedHash([]byte(salt))>>11) / (1 << 53) * 100
+}
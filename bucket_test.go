@@ -0,0 +1,82 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucket(t *testing.T) {
+	salt := []byte("checkout-v2")
+
+	t.Run("is deterministic and in range", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			x := xuid.MustNewRandom("user")
+
+			b := x.Bucket(10, salt)
+
+			assert.GreaterOrEqual(t, b, 0)
+			assert.Less(t, b, 10)
+			assert.Equal(t, b, x.Bucket(10, salt))
+		}
+	})
+
+	t.Run("matches reference value", func(t *testing.T) {
+		x, _ := xuid.NewWith(uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057"), "user")
+
+		assert.Equal(t, 15, x.Bucket(100, salt))
+	})
+
+	t.Run("ignores the prefix", func(t *testing.T) {
+		x := xuid.MustNewRandom("user")
+		y, _ := xuid.NewWith(x.GetUUID(), "")
+
+		assert.Equal(t, x.Bucket(100, salt), y.Bucket(100, salt))
+	})
+
+	t.Run("spreads IDs across buckets", func(t *testing.T) {
+		counts := make([]int, 4)
+		for i := 0; i < 4000; i++ {
+			counts[xuid.MustNewRandom("").Bucket(4, salt)]++
+		}
+		for _, c := range counts {
+			assert.InDelta(t, 1000, c, 200)
+		}
+	})
+
+	t.Run("panics on non-positive n", func(t *testing.T) {
+		assert.Panics(t, func() { xuid.MustNewRandom("").Bucket(0, salt) })
+	})
+}
+
+func TestRolloutPercent(t *testing.T) {
+	t.Run("is deterministic and in range", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			x := xuid.MustNewRandom("user")
+
+			p := x.RolloutPercent("checkout-v2")
+
+			assert.GreaterOrEqual(t, p, 0.0)
+			assert.Less(t, p, 100.0)
+			assert.Equal(t, p, x.RolloutPercent("checkout-v2"))
+		}
+	})
+
+	t.Run("matches reference value", func(t *testing.T) {
+		x, _ := xuid.NewWith(uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057"), "user")
+
+		assert.InDelta(t, 15.9536, x.RolloutPercent("checkout-v2"), 1e-4)
+	})
+
+	t.Run("enables about p percent of IDs", func(t *testing.T) {
+		enabled := 0
+		for i := 0; i < 4000; i++ {
+			if xuid.MustNewRandom("").RolloutPercent("checkout-v2") < 25 {
+				enabled++
+			}
+		}
+		assert.InDelta(t, 1000, enabled, 200)
+	})
+}
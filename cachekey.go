@@ -0,0 +1,44 @@
+package xuid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// cacheKeyPartLen bounds the namespace and prefix in cache keys.
+const cacheKeyPartLen = 64
+
+// MaxCacheKeyLen is the maximum length of keys returned by CacheKey, well
+// below the 250 byte limit of memcached.
+const MaxCacheKeyLen = cacheKeyPartLen + 1 + cacheKeyPartLen + len(Separator) + EncodedLenMax
+
+// CacheKey returns a key for caches such as memcached and groupcache, of the
+// form "namespace:prefix_payload". Keys are at most MaxCacheKeyLen bytes and
+// only contain ASCII letters, digits and "-._:~".
+// A namespace or prefix longer than 64 bytes, or containing other
+// characters, is replaced by "~" followed by a hash of its value, so keys
+// stay distinct. The namespace and its colon are omitted when empty.
+func (x XUID) CacheKey(namespace string) string {
+	var b strings.Builder
+	if namespace != "" {
+		b.WriteString(cacheKeyPart(namespace))
+		b.WriteByte(':')
+	}
+	if x.prefix != "" {
+		b.WriteString(cacheKeyPart(x.prefix))
+		b.WriteString(Separator)
+	}
+	b.WriteString(x.payload())
+	return b.String()
+}
+
+func cacheKeyPart(s string) string {
+	if len(s) <= cacheKeyPartLen && strings.Trim(s, cacheKeyAlphabet) == "" {
+		return s
+	}
+	sum := sha256.Sum256([]byte(s))
+	return "~" + hex.EncodeToString(sum[:16])
+}
+
+const cacheKeyAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-._"
@@ -0,0 +1,57 @@
+package xuid_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheKey(t *testing.T) {
+	id := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	x, err := xuid.NewWith(id, "user")
+	require.NoError(t, err)
+
+	t.Run("joins namespace and string form", func(t *testing.T) {
+		assert.Equal(t, "sessions:"+x.String(), x.CacheKey("sessions"))
+	})
+
+	t.Run("omits empty namespace and prefix", func(t *testing.T) {
+		bare := xuid.Must(xuid.NewWith(id, ""))
+
+		assert.Equal(t, x.String(), x.CacheKey(""))
+		assert.Equal(t, bare.String(), bare.CacheKey(""))
+	})
+
+	t.Run("hashes long and unsafe parts", func(t *testing.T) {
+		long := xuid.Must(xuid.NewWith(id, strings.Repeat("p", xuid.MaxPrefixLen)))
+		spaced := xuid.Must(xuid.NewWith(id, "user profile"))
+
+		for _, key := range []string{
+			long.CacheKey("sessions"),
+			spaced.CacheKey("sessions"),
+			x.CacheKey(strings.Repeat("n", 65)),
+			x.CacheKey("a:b"),
+			x.CacheKey("café"),
+		} {
+			assert.LessOrEqual(t, len(key), xuid.MaxCacheKeyLen, key)
+			assert.Empty(t, strings.Trim(key, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-._:~"), key)
+			assert.Contains(t, key, "~")
+		}
+	})
+
+	t.Run("keeps hashed keys distinct", func(t *testing.T) {
+		a := xuid.Must(xuid.NewWith(id, "user profile"))
+		b := xuid.Must(xuid.NewWith(id, "user  profile"))
+
+		assert.NotEqual(t, a.CacheKey("ns"), b.CacheKey("ns"))
+		assert.NotEqual(t, x.CacheKey("a b"), x.CacheKey("a  b"))
+	})
+
+	t.Run("fits memcached limit", func(t *testing.T) {
+		assert.LessOrEqual(t, xuid.MaxCacheKeyLen, 250)
+	})
+}
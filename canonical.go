@@ -0,0 +1,46 @@
+package xuid
+
+// CanonicalBytes returns the canonical serialization of x: the length of
+// the prefix in one byte, the prefix and the 16 UUID bytes. It does not
+// depend on Config.Encoding or any other setting, and distinct XUIDs
+// always serialize differently, so signatures and HMACs computed over it
+// by different services agree. It fails with ErrPrefixTooLong if the
+// prefix, which SetPrefix does not validate, exceeds MaxPrefixLen.
+func (x XUID) CanonicalBytes() ([]byte, error) {
+	return x.AppendCanonicalBytes(make([]byte, 0, 1+len(x.prefix)+16))
+}
+
+// AppendCanonicalBytes appends the canonical serialization of x to dst.
+// dst is returned unchanged on error.
+func (x XUID) AppendCanonicalBytes(dst []byte) ([]byte, error) {
+	if len(x.prefix) > MaxPrefixLen {
+		return dst, ErrPrefixTooLong
+	}
+	dst = append(dst, byte(len(x.prefix)))
+	dst = append(dst, x.prefix...)
+	return append(dst, x.uuid[:]...), nil
+}
+
+// ParseCanonicalBytes is the inverse of CanonicalBytes.
+func ParseCanonicalBytes(b []byte) (XUID, error) {
+	x, rest, ok := readCanonical(b)
+	if !ok || len(rest) != 0 {
+		return XUID{}, ErrInvalidCanonicalBytes
+	}
+	return NewWith(x.uuid, x.prefix)
+}
+
+// readCanonical reads the canonical serialization of a XUID at the start of
+// data and returns the rest of data.
+func readCanonical(data []byte) (XUID, []byte, bool) {
+	if len(data) == 0 {
+		return XUID{}, nil, false
+	}
+	n := int(data[0])
+	if n > MaxPrefixLen || len(data) < 1+n+16 {
+		return XUID{}, nil, false
+	}
+	x := XUID{prefix: internPrefix(string(data[1 : 1+n]))}
+	copy(x.uuid[:], data[1+n:1+n+16])
+	return x, data[1+n+16:], true
+}
@@ -0,0 +1,70 @@
+package xuid_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// canonicalBytes returns the canonical serialization of x, failing the test
+// on error.
+func canonicalBytes(t *testing.T, x xuid.XUID) []byte {
+	t.Helper()
+	b, err := x.CanonicalBytes()
+	require.NoError(t, err)
+	return b
+}
+
+func TestCanonicalBytes(t *testing.T) {
+	u := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+
+	t.Run("serializes the prefix length, prefix and UUID", func(t *testing.T) {
+		b := canonicalBytes(t, xuid.Must(xuid.NewWith(u, "user")))
+
+		assert.Equal(t, append([]byte{4, 'u', 's', 'e', 'r'}, u[:]...), b)
+	})
+
+	t.Run("does not depend on the encoding", func(t *testing.T) {
+		id := xuid.Must(xuid.NewWith(u, "user"))
+		want := canonicalBytes(t, id)
+		withConfig(t, xuid.Config{Encoding: xuid.EncodingLengthPrefixed})
+
+		assert.Equal(t, want, canonicalBytes(t, id))
+	})
+
+	t.Run("round-trips", func(t *testing.T) {
+		for _, prefix := range []string{"", "user", "user_profile"} {
+			id := xuid.Must(xuid.NewWith(u, prefix))
+
+			parsed, err := xuid.ParseCanonicalBytes(canonicalBytes(t, id))
+
+			require.NoError(t, err)
+			assert.Equal(t, id, parsed)
+		}
+	})
+
+	t.Run("rejects prefixes longer than MaxPrefixLen", func(t *testing.T) {
+		id := xuid.Must(xuid.NewWith(u, ""))
+		id.SetPrefix(strings.Repeat("a", 256+4))
+
+		_, err := id.CanonicalBytes()
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+
+		dst, err := id.AppendCanonicalBytes([]byte{1})
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+		assert.Equal(t, []byte{1}, dst)
+	})
+
+	t.Run("rejects invalid bytes", func(t *testing.T) {
+		valid := canonicalBytes(t, xuid.Must(xuid.NewWith(u, "user")))
+		for _, b := range [][]byte{nil, valid[:len(valid)-1], append(valid, 0), {200}} {
+			_, err := xuid.ParseCanonicalBytes(b)
+
+			assert.ErrorIs(t, err, xuid.ErrInvalidCanonicalBytes)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package xuid
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// CanonicalHashKey is the fixed 16 byte SipHash key used by CanonicalHash.
+// It is part of the wire format and never changes.
+const CanonicalHashKey = "xuid-canonical-1"
+
+// Test vectors of CanonicalHash, for checking implementations in other
+// languages.
+const (
+	CanonicalHashVectorUUID = "01890a5d-ac96-774b-bcce-b302099a8057"
+	CanonicalHashVector     = 0xdef9e46f1eb5f590
+	CanonicalHashVectorNil  = 0xb68783c15134b151
+	CanonicalHashVectorMax  = 0x04d056ec761f8dc7
+)
+
+// CanonicalHash returns the SipHash-2-4 of the 16 UUID bytes, in RFC 4122
+// order, keyed with CanonicalHashKey. The prefix does not take part in the
+// hash.
+//
+// Unlike Hash64, whose algorithm may change, CanonicalHash is specified so
+// that services in any language can compute identical shard or bucket
+// values: SipHash-2-4 is available in most standard libraries, and the
+// result is the 64-bit SipHash output read as a little-endian integer.
+func (x XUID) CanonicalHash() uint64 {
+	k0 := binary.LittleEndian.Uint64([]byte(CanonicalHashKey[:8]))
+	k1 := binary.LittleEndian.Uint64([]byte(CanonicalHashKey[8:]))
+	return sipHash24(k0, k1, x.uuid)
+}
+
+// sipHash24 is SipHash-2-4 specialized for 16 byte messages.
+func sipHash24(k0, k1 uint64, msg [16]byte) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13) ^ v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16) ^ v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21) ^ v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17) ^ v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+	// The final block holds only the message length, as 16 is a multiple
+	// of the block size.
+	for _, m := range [3]uint64{
+		binary.LittleEndian.Uint64(msg[:8]),
+		binary.LittleEndian.Uint64(msg[8:]),
+		uint64(len(msg)) << 56,
+	} {
+		v3 ^= m
+		round()
+		round()
+		v0 ^= m
+	}
+	v2 ^= 0xff
+	round()
+	round()
+	round()
+	round()
+	return v0 ^ v1 ^ v2 ^ v3
+}
@@ -0,0 +1,28 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalHash(t *testing.T) {
+	t.Run("matches test vectors", func(t *testing.T) {
+		x, _ := xuid.NewWith(uuid.MustParse(xuid.CanonicalHashVectorUUID), "user")
+		nilID, _ := xuid.NewWith(uuid.Nil, "")
+		maxID, _ := xuid.NewWith(uuid.Max, "")
+
+		assert.Equal(t, uint64(xuid.CanonicalHashVector), x.CanonicalHash())
+		assert.Equal(t, uint64(xuid.CanonicalHashVectorNil), nilID.CanonicalHash())
+		assert.Equal(t, uint64(xuid.CanonicalHashVectorMax), maxID.CanonicalHash())
+	})
+
+	t.Run("ignores the prefix", func(t *testing.T) {
+		x := xuid.MustNewRandom("user")
+		y, _ := xuid.NewWith(x.GetUUID(), "")
+
+		assert.Equal(t, x.CanonicalHash(), y.CanonicalHash())
+	})
+}
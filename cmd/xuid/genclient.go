@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/47monad/xuid"
+)
+
+// genClient writes a dependency-free JavaScript or TypeScript module that
+// parses and formats XUIDs in the default encoding. Its constants come from
+// the xuid package, so regenerating it keeps clients in sync with Go.
+func genClient(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("gen-client", flag.ContinueOnError)
+	fs.SetOutput(w)
+	lang := fs.String("lang", "ts", "language of the generated module: ts or js")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *lang != "ts" && *lang != "js" {
+		return fmt.Errorf("unsupported language %q, want ts or js", *lang)
+	}
+	ts := *lang == "ts"
+	tmpl := template.Must(template.New("client").Funcs(template.FuncMap{
+		// ts returns s in TypeScript output only, for type annotations.
+		"ts": func(s string) string {
+			if ts {
+				return s
+			}
+			return ""
+		},
+	}).Parse(clientTemplate))
+	return tmpl.Execute(w, map[string]any{
+		"Alphabet":      xuid.Base58Alphabet,
+		"Separator":     xuid.Separator,
+		"MaxPrefixLen":  xuid.MaxPrefixLen,
+		"EncodedLenMin": xuid.EncodedLenMin,
+		"EncodedLenMax": xuid.EncodedLenMax,
+	})
+}
+
+const clientTemplate = `// Code generated by "xuid gen-client"; DO NOT EDIT.
+//
+// Parses and formats XUIDs of the form "prefix_payload", where payload is
+// the base58 encoding of the 16 UUID bytes. Prefixes may contain the
+// separator: strings are split at the last one.
+
+export const ALPHABET = {{printf "%q" .Alphabet}};
+export const SEPARATOR = {{printf "%q" .Separator}};
+export const MAX_PREFIX_LEN = {{.MaxPrefixLen}};
+export const ENCODED_LEN_MIN = {{.EncodedLenMin}};
+export const ENCODED_LEN_MAX = {{.EncodedLenMax}};
+{{if ts "ts"}}
+export interface XUID {
+  /** Prefix, or "" if there is none. */
+  prefix: string;
+  /** Lowercase hyphenated UUID. */
+  uuid: string;
+}
+{{end}}
+/** Parses a XUID string, throwing an Error if it is invalid. */
+export function parse(s{{ts ": string"}}){{ts ": XUID"}} {
+  const i = s.lastIndexOf(SEPARATOR);
+  const prefix = i >= 0 ? s.slice(0, i) : "";
+  const payload = s.slice(i + 1);
+  if (new TextEncoder().encode(prefix).length > MAX_PREFIX_LEN) {
+    throw new Error("xuid: prefix is too long");
+  }
+  if (payload.length < ENCODED_LEN_MIN || payload.length > ENCODED_LEN_MAX) {
+    throw new Error("xuid: payload does not encode 16 bytes");
+  }
+  return { prefix, uuid: bytesToUUID(decodeBase58(payload)) };
+}
+
+/** Reports whether s is a valid XUID string. */
+export function isValid(s{{ts ": string"}}){{ts ": boolean"}} {
+  try {
+    parse(s);
+    return true;
+  } catch {
+    return false;
+  }
+}
+
+/** Formats a XUID as a string, throwing an Error if it is invalid. */
+export function format(x{{ts ": XUID"}}){{ts ": string"}} {
+  if (new TextEncoder().encode(x.prefix).length > MAX_PREFIX_LEN) {
+    throw new Error("xuid: prefix is too long");
+  }
+  const payload = encodeBase58(uuidToBytes(x.uuid));
+  return x.prefix === "" ? payload : x.prefix + SEPARATOR + payload;
+}
+
+function encodeBase58(bytes{{ts ": Uint8Array"}}){{ts ": string"}} {
+  let zeros = 0;
+  while (zeros < bytes.length && bytes[zeros] === 0) zeros++;
+  const digits{{ts ": number[]"}} = [];
+  for (const b of bytes) {
+    let carry = b;
+    for (let j = 0; j < digits.length; j++) {
+      carry += digits[j] * 256;
+      digits[j] = carry % 58;
+      carry = Math.floor(carry / 58);
+    }
+    while (carry > 0) {
+      digits.push(carry % 58);
+      carry = Math.floor(carry / 58);
+    }
+  }
+  let s = ALPHABET[0].repeat(zeros);
+  for (let i = digits.length - 1; i >= 0; i--) s += ALPHABET[digits[i]];
+  return s;
+}
+
+function decodeBase58(s{{ts ": string"}}){{ts ": Uint8Array"}} {
+  const bytes{{ts ": number[]"}} = [];
+  for (const c of s) {
+    let carry = ALPHABET.indexOf(c);
+    if (carry < 0) throw new Error("xuid: invalid character " + JSON.stringify(c) + " in payload");
+    for (let j = 0; j < bytes.length; j++) {
+      carry += bytes[j] * 58;
+      bytes[j] = carry & 0xff;
+      carry >>= 8;
+    }
+    while (carry > 0) {
+      bytes.push(carry & 0xff);
+      carry >>= 8;
+    }
+  }
+  let zeros = 0;
+  while (zeros < s.length && s[zeros] === ALPHABET[0]) zeros++;
+  const out = new Uint8Array(zeros + bytes.length);
+  for (let i = 0; i < bytes.length; i++) out[out.length - 1 - i] = bytes[i];
+  if (out.length !== 16) throw new Error("xuid: payload does not encode 16 bytes");
+  return out;
+}
+
+function uuidToBytes(uuid{{ts ": string"}}){{ts ": Uint8Array"}} {
+  const hex = uuid.replace(/-/g, "");
+  if (!/^[0-9a-fA-F]{32}$/.test(hex)) throw new Error("xuid: invalid UUID");
+  const out = new Uint8Array(16);
+  for (let i = 0; i < 16; i++) out[i] = parseInt(hex.slice(2 * i, 2 * i + 2), 16);
+  return out;
+}
+
+function bytesToUUID(bytes{{ts ": Uint8Array"}}){{ts ": string"}} {
+  const hex = Array.from(bytes, (b) => b.toString(16).padStart(2, "0")).join("");
+  return hex.slice(0, 8) + "-" + hex.slice(8, 12) + "-" + hex.slice(12, 16) + "-" + hex.slice(16, 20) + "-" + hex.slice(20);
+}
+`
@@ -0,0 +1,90 @@
+// Command xuid inspects and generates XUIDs.
+//
+// Usage:
+//
+//	xuid inspect <xuid>...
+//	xuid new [-random] [prefix]
+//	xuid gen-client [-lang ts|js]
+//	xuid rename-prefix -table <table> -column <column> [-dialect d] [-prefix-column] <old> <new>
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/47monad/xuid"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "xuid:", err)
+		os.Exit(1)
+	}
+}
+
+var errUsage = errors.New("usage: xuid <inspect|new|gen-client|rename-prefix> [arguments]")
+
+func run(args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "inspect":
+		return inspect(args[1:], w)
+	case "new":
+		return generate(args[1:], w)
+	case "gen-client":
+		return genClient(args[1:], w)
+	case "rename-prefix":
+		return renamePrefix(args[1:], w)
+	}
+	return errUsage
+}
+
+func inspect(args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: xuid inspect <xuid>...")
+	}
+	for i, s := range args {
+		info, err := xuid.InspectString(s)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "prefix:      %s\n", info.Prefix)
+		fmt.Fprintf(w, "payload:     %s\n", info.Payload)
+		fmt.Fprintf(w, "uuid:        %s\n", info.UUID)
+		fmt.Fprintf(w, "version:     %d\n", info.Version)
+		fmt.Fprintf(w, "variant:     %s\n", info.Variant)
+		if !info.Time.IsZero() {
+			fmt.Fprintf(w, "time:        %s\n", info.Time.UTC().Format(time.RFC3339Nano))
+		}
+		fmt.Fprintf(w, "encoded len: %d\n", info.EncodedLen)
+	}
+	return nil
+}
+
+func generate(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	fs.SetOutput(w)
+	random := fs.Bool("random", false, "generate a random (UUIDv4) XUID instead of a sortable one")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	newFn := xuid.NewSortable
+	if *random {
+		newFn = xuid.NewRandom
+	}
+	id, err := newFn(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, id)
+	return nil
+}
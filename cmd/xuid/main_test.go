@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspect(t *testing.T) {
+	t.Run("prints XUID details", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+		var out bytes.Buffer
+
+		err := run([]string{"inspect", id.String()}, &out)
+
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "prefix:      user\n")
+		assert.Contains(t, out.String(), "uuid:        "+id.GetUUID().String()+"\n")
+		assert.Contains(t, out.String(), "version:     7\n")
+		assert.Contains(t, out.String(), "time:        ")
+	})
+
+	t.Run("returns parse errors", func(t *testing.T) {
+		err := run([]string{"inspect", "user_abcO123"}, &bytes.Buffer{})
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("prints sortable XUID by default", func(t *testing.T) {
+		var out bytes.Buffer
+
+		require.NoError(t, run([]string{"new", "user"}, &out))
+
+		id, err := xuid.Parse(strings.TrimSpace(out.String()))
+		require.NoError(t, err)
+		assert.True(t, id.IsSortable())
+		assert.Equal(t, "user", id.GetPrefix())
+	})
+
+	t.Run("prints random XUID", func(t *testing.T) {
+		var out bytes.Buffer
+
+		require.NoError(t, run([]string{"new", "-random"}, &out))
+
+		id, err := xuid.Parse(strings.TrimSpace(out.String()))
+		require.NoError(t, err)
+		assert.True(t, id.IsRandom())
+	})
+}
+
+func TestGenClient(t *testing.T) {
+	t.Run("generates TypeScript from package constants", func(t *testing.T) {
+		var out bytes.Buffer
+
+		require.NoError(t, run([]string{"gen-client", "--lang", "ts"}, &out))
+
+		assert.Contains(t, out.String(), `export const ALPHABET = "`+xuid.Base58Alphabet+`";`)
+		assert.Contains(t, out.String(), "export const MAX_PREFIX_LEN = 128;")
+		assert.Contains(t, out.String(), "export interface XUID {")
+		assert.Contains(t, out.String(), "export function parse(s: string): XUID {")
+	})
+
+	t.Run("generates JavaScript without type annotations", func(t *testing.T) {
+		var out bytes.Buffer
+
+		require.NoError(t, run([]string{"gen-client", "-lang", "js"}, &out))
+
+		assert.Contains(t, out.String(), "export function parse(s) {")
+		assert.NotContains(t, out.String(), "interface")
+		assert.NotContains(t, out.String(), "(s: string)")
+	})
+
+	t.Run("rejects unknown languages", func(t *testing.T) {
+		assert.Error(t, run([]string{"gen-client", "--lang", "py"}, &bytes.Buffer{}))
+	})
+}
+
+func TestRenamePrefix(t *testing.T) {
+	t.Run("prints the SQL statements", func(t *testing.T) {
+		var out bytes.Buffer
+
+		err := run([]string{"rename-prefix", "-table", "projects", "-column", "prefix", "-prefix-column", "proj", "workspace"}, &out)
+
+		require.NoError(t, err)
+		assert.Equal(t, "UPDATE projects SET prefix = 'workspace' WHERE prefix = 'proj';\n", out.String())
+	})
+
+	t.Run("requires the table, column and prefixes", func(t *testing.T) {
+		err := run([]string{"rename-prefix", "-table", "projects", "proj", "workspace"}, &bytes.Buffer{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestUsage(t *testing.T) {
+	assert.ErrorIs(t, run(nil, &bytes.Buffer{}), errUsage)
+	assert.ErrorIs(t, run([]string{"unknown"}, &bytes.Buffer{}), errUsage)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidmigrate"
+)
+
+// renamePrefix prints the SQL renaming a XUID prefix stored in a column.
+func renamePrefix(args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("rename-prefix", flag.ContinueOnError)
+	fs.SetOutput(w)
+	dialect := fs.String("dialect", string(xuid.DialectPostgresText), "SQL dialect of the database")
+	table := fs.String("table", "", "table holding the XUIDs")
+	column := fs.String("column", "", "column holding the XUIDs")
+	prefixColumn := fs.Bool("prefix-column", false, "the column holds only prefixes, as written by xuidsql.SplitArgs")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 || *table == "" || *column == "" {
+		return errors.New("usage: xuid rename-prefix -table <table> -column <column> [-dialect d] [-prefix-column] <old> <new>")
+	}
+	stmts, err := xuidmigrate.Rename{
+		Dialect:      xuid.Dialect(*dialect),
+		Table:        *table,
+		Column:       *column,
+		PrefixColumn: *prefixColumn,
+		Old:          fs.Arg(0),
+		New:          fs.Arg(1),
+	}.Up()
+	if err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		fmt.Fprintf(w, "%s;\n", stmt)
+	}
+	return nil
+}
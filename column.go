@@ -0,0 +1,116 @@
+package xuid
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Column stores XUIDs sharing a prefix as a contiguous byte slice of 16-byte
+// UUIDs. It uses 16 bytes per XUID, against 40 bytes or more for a []XUID,
+// and holds a single pointer, so large columns are cheap for the garbage
+// collector to scan. The zero value is an empty column without prefix.
+type Column struct {
+	prefix string
+	data   []byte
+}
+
+// NewColumn returns an empty column of XUIDs with prefix, with room for n
+// XUIDs before growing.
+func NewColumn(prefix string, n int) *Column {
+	return &Column{prefix: prefix, data: make([]byte, 0, n*16)}
+}
+
+// Prefix returns the prefix shared by the XUIDs of the column.
+func (c *Column) Prefix() string {
+	return c.prefix
+}
+
+// Len returns the number of XUIDs in the column.
+func (c *Column) Len() int {
+	return len(c.data) / 16
+}
+
+// Append adds ids to the end of the column. It fails with
+// ErrColumnPrefix, without adding any XUID, if a prefix differs from the
+// column prefix.
+func (c *Column) Append(ids ...XUID) error {
+	for _, x := range ids {
+		if x.prefix != c.prefix {
+			return ErrColumnPrefix
+		}
+	}
+	for _, x := range ids {
+		c.data = append(c.data, x.uuid[:]...)
+	}
+	return nil
+}
+
+// AppendUUID adds ids to the end of the column.
+func (c *Column) AppendUUID(ids ...uuid.UUID) {
+	for _, id := range ids {
+		c.data = append(c.data, id[:]...)
+	}
+}
+
+// At returns the i-th XUID of the column. It panics if i is out of range.
+func (c *Column) At(i int) XUID {
+	return XUID{uuid: c.UUIDAt(i), prefix: c.prefix}
+}
+
+// UUIDAt returns the UUID of the i-th XUID of the column. It panics if i is
+// out of range.
+func (c *Column) UUIDAt(i int) uuid.UUID {
+	return uuid.UUID(c.data[i*16 : i*16+16])
+}
+
+// Range calls f for each XUID of the column in order, until f returns
+// false.
+func (c *Column) Range(f func(i int, x XUID) bool) {
+	for i := 0; i < c.Len(); i++ {
+		if !f(i, c.At(i)) {
+			return
+		}
+	}
+}
+
+// Sort sorts the column in the order defined by Compare.
+func (c *Column) Sort() {
+	sort.Sort(columnSorter{c})
+}
+
+// Search returns the position of x in a sorted column, or the position
+// where it would be inserted, and whether it was found. XUIDs with another
+// prefix are never found.
+func (c *Column) Search(x XUID) (int, bool) {
+	i := sort.Search(c.Len(), func(i int) bool {
+		return bytes.Compare(c.data[i*16:i*16+16], x.uuid[:]) >= 0
+	})
+	found := i < c.Len() && x.prefix == c.prefix && bytes.Equal(c.data[i*16:i*16+16], x.uuid[:])
+	return i, found
+}
+
+// Slice returns the XUIDs of the column.
+func (c *Column) Slice() []XUID {
+	res := make([]XUID, c.Len())
+	for i := range res {
+		res[i] = c.At(i)
+	}
+	return res
+}
+
+type columnSorter struct{ c *Column }
+
+func (s columnSorter) Len() int { return s.c.Len() }
+
+func (s columnSorter) Less(i, j int) bool {
+	return bytes.Compare(s.c.data[i*16:i*16+16], s.c.data[j*16:j*16+16]) < 0
+}
+
+func (s columnSorter) Swap(i, j int) {
+	var tmp [16]byte
+	copy(tmp[:], s.c.data[i*16:i*16+16])
+	copy(s.c.data[i*16:i*16+16], s.c.data[j*16:j*16+16])
+	copy(s.c.data[j*16:j*16+16], tmp[:])
+}
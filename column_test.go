@@ -0,0 +1,78 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumn(t *testing.T) {
+	t.Run("appends and reads XUIDs", func(t *testing.T) {
+		ids := []xuid.XUID{xuid.MustNewSortable("user"), xuid.MustNewRandom("user")}
+		c := xuid.NewColumn("user", 2)
+
+		require.NoError(t, c.Append(ids...))
+
+		assert.Equal(t, 2, c.Len())
+		assert.Equal(t, "user", c.Prefix())
+		assert.Equal(t, ids[1], c.At(1))
+		assert.Equal(t, ids[0].GetUUID(), c.UUIDAt(0))
+		assert.Equal(t, ids, c.Slice())
+	})
+
+	t.Run("rejects XUIDs with another prefix", func(t *testing.T) {
+		c := xuid.NewColumn("user", 0)
+
+		err := c.Append(xuid.MustNewSortable("user"), xuid.MustNewSortable("order"))
+
+		assert.ErrorIs(t, err, xuid.ErrColumnPrefix)
+		assert.Equal(t, 0, c.Len())
+	})
+
+	t.Run("zero value holds XUIDs without prefix", func(t *testing.T) {
+		var c xuid.Column
+		x := xuid.MustNewSortable("")
+
+		require.NoError(t, c.Append(x))
+		assert.Equal(t, x, c.At(0))
+	})
+
+	t.Run("ranges until f returns false", func(t *testing.T) {
+		c := xuid.NewColumn("user", 0)
+		require.NoError(t, c.Append(xuid.MustNewSortable("user"), xuid.MustNewSortable("user"), xuid.MustNewSortable("user")))
+
+		var seen []int
+		c.Range(func(i int, x xuid.XUID) bool {
+			seen = append(seen, i)
+			return i < 1
+		})
+
+		assert.Equal(t, []int{0, 1}, seen)
+	})
+
+	t.Run("sorts and searches", func(t *testing.T) {
+		ids := make([]xuid.XUID, 50)
+		c := xuid.NewColumn("user", len(ids))
+		for i := range ids {
+			ids[i] = xuid.MustNewRandom("user")
+			c.AppendUUID(ids[i].GetUUID())
+		}
+
+		c.Sort()
+		xuid.Sort(ids)
+
+		assert.Equal(t, ids, c.Slice())
+		for i, x := range ids {
+			pos, found := c.Search(x)
+			assert.True(t, found)
+			assert.Equal(t, i, pos)
+		}
+		_, found := c.Search(xuid.MustNewRandom("user"))
+		assert.False(t, found)
+		other, _ := xuid.NewWith(ids[3].GetUUID(), "order")
+		_, found = c.Search(other)
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,101 @@
+package xuid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CompactEncoder shortens XUIDs written to a stream, such as a log file,
+// by replacing prefixes with indexes into a dictionary built as the stream
+// is written. The first XUID with a given prefix is encoded as
+// "<index>=<prefix>:<payload>", which defines the index, and later ones as
+// "<index>:<payload>". XUIDs without a prefix are encoded as their payload.
+//
+// Tokens must be decoded by a single CompactDecoder in the order they were
+// encoded, so use one encoder per stream and start a new one when the
+// stream is rotated. A CompactEncoder is not safe for concurrent use. The
+// zero value is ready to use.
+type CompactEncoder struct {
+	dict map[string]int
+}
+
+// NewCompactEncoder returns an encoder with an empty dictionary.
+func NewCompactEncoder() *CompactEncoder {
+	return &CompactEncoder{}
+}
+
+// Encode returns the token of x.
+func (e *CompactEncoder) Encode(x XUID) string {
+	return string(e.Append(nil, x))
+}
+
+// Append appends the token of x to dst.
+func (e *CompactEncoder) Append(dst []byte, x XUID) []byte {
+	if x.prefix != "" {
+		i, ok := e.dict[x.prefix]
+		if !ok {
+			if e.dict == nil {
+				e.dict = map[string]int{}
+			}
+			i = len(e.dict)
+			e.dict[x.prefix] = i
+		}
+		dst = strconv.AppendInt(dst, int64(i), 10)
+		if !ok {
+			dst = append(dst, '=')
+			dst = append(dst, x.prefix...)
+		}
+		dst = append(dst, ':')
+	}
+	return appendBase58(dst, x.uuid)
+}
+
+// CompactDecoder decodes the tokens of a CompactEncoder. A CompactDecoder
+// is not safe for concurrent use. The zero value is ready to use.
+type CompactDecoder struct {
+	dict []string
+}
+
+// NewCompactDecoder returns a decoder with an empty dictionary.
+func NewCompactDecoder() *CompactDecoder {
+	return &CompactDecoder{}
+}
+
+// Decode returns the XUID of token. Tokens referring to an index that was
+// not defined by a previous token, such as after a lost record, fail with
+// ErrInvalidCompactToken.
+func (d *CompactDecoder) Decode(token string) (XUID, error) {
+	colon := strings.LastIndexByte(token, ':')
+	p, id, err := parse(token[colon+1:])
+	if err != nil {
+		return XUID{}, err
+	}
+	if p != "" {
+		return XUID{}, fmt.Errorf("%w: prefixed payload in %q", ErrInvalidCompactToken, token)
+	}
+	if colon < 0 {
+		return XUID{uuid: id}, nil
+	}
+	ref, prefix, define := strings.Cut(token[:colon], "=")
+	i, err := strconv.Atoi(ref)
+	if err != nil || i < 0 || ref[0] == '+' {
+		return XUID{}, fmt.Errorf("%w: invalid index in %q", ErrInvalidCompactToken, token)
+	}
+	if define {
+		if prefix == "" || i != len(d.dict) {
+			return XUID{}, fmt.Errorf("%w: invalid definition of index %d", ErrInvalidCompactToken, i)
+		}
+		x, err := NewWith(id, prefix)
+		if err != nil {
+			return XUID{}, err
+		}
+		x.prefix = internPrefix(prefix)
+		d.dict = append(d.dict, x.prefix)
+		return x, nil
+	}
+	if i >= len(d.dict) {
+		return XUID{}, fmt.Errorf("%w: index %d is not defined", ErrInvalidCompactToken, i)
+	}
+	return XUID{uuid: id, prefix: d.dict[i]}, nil
+}
@@ -0,0 +1,61 @@
+package xuid_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactEncoder(t *testing.T) {
+	user1, user2, org := xuid.MustNewSortable("user"), xuid.MustNewSortable("user"), xuid.MustNewSortable("org")
+	bare := xuid.MustNewSortable("")
+
+	t.Run("defines prefixes once", func(t *testing.T) {
+		enc := xuid.NewCompactEncoder()
+
+		tokens := []string{enc.Encode(user1), enc.Encode(org), enc.Encode(user2), enc.Encode(bare)}
+
+		payload := func(x xuid.XUID) string { return strings.TrimPrefix(x.String(), x.GetPrefix()+"_") }
+		assert.Equal(t, []string{
+			"0=user:" + payload(user1),
+			"1=org:" + payload(org),
+			"0:" + payload(user2),
+			bare.String(),
+		}, tokens)
+	})
+
+	t.Run("round-trips through a decoder", func(t *testing.T) {
+		var enc xuid.CompactEncoder
+		var dec xuid.CompactDecoder
+
+		for _, x := range []xuid.XUID{user1, org, bare, user2, org} {
+			got, err := dec.Decode(string(enc.Append(nil, x)))
+
+			require.NoError(t, err)
+			assert.Equal(t, x, got)
+		}
+	})
+
+	t.Run("rejects undefined indexes", func(t *testing.T) {
+		enc, dec := xuid.NewCompactEncoder(), xuid.NewCompactDecoder()
+		enc.Encode(user1)
+
+		_, err := dec.Decode(enc.Encode(user2))
+
+		assert.ErrorIs(t, err, xuid.ErrInvalidCompactToken)
+	})
+
+	t.Run("rejects malformed tokens", func(t *testing.T) {
+		payload := strings.TrimPrefix(user1.String(), "user_")
+		for _, token := range []string{"x:" + payload, "-1:" + payload, "+0=user:" + payload, "1=user:" + payload, "0=:" + payload, "0=user:abc_" + payload, "abc_" + payload} {
+			_, err := xuid.NewCompactDecoder().Decode(token)
+
+			assert.ErrorIs(t, err, xuid.ErrInvalidCompactToken, token)
+		}
+		_, err := xuid.NewCompactDecoder().Decode("0=user:0OIl")
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+}
@@ -0,0 +1,145 @@
+package xuid
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Config holds package-wide settings.
+// Settings apply to every package using this one, so change them once
+// during program initialization with SetConfig.
+type Config struct {
+	// SeparatorPolicy controls whether prefixes may contain Separator.
+	SeparatorPolicy SeparatorPolicy
+	// Encoding selects the string form produced by String and accepted by
+	// Parse.
+	Encoding Encoding
+	// SQLStorage selects the representation used by Value and Scan.
+	SQLStorage SQLStorage
+	// SubjectSeparator separates the prefix from the payload in
+	// SubjectString. It defaults to ":" and must not contain base58
+	// characters.
+	SubjectSeparator string
+	// MaxFutureSkew makes Parse reject sortable XUIDs whose timestamp is
+	// more than MaxFutureSkew after the current time, which catches
+	// corrupted or forged IDs at ingestion boundaries. Zero disables the
+	// check.
+	MaxFutureSkew time.Duration
+	// SortableFallback selects what NewSortable does when it cannot create
+	// a UUIDv7.
+	SortableFallback SortableFallback
+	// OnSortableFallback, if set, is called with the error of every UUIDv7
+	// replaced under SortableFallbackRandom, for logging and alerting.
+	OnSortableFallback func(err error)
+	// LegacyLayout makes Parse accept strings in a layout of older
+	// versions when they do not parse in the current one. It has no effect
+	// with Strict.
+	LegacyLayout LegacyLayout
+	// Strict turns input that is otherwise accepted and normalized into
+	// errors, for services that must not store anything but canonical
+	// XUIDs:
+	//   - prefixes containing Separator are rejected, as with
+	//     SeparatorForbid, whatever SeparatorPolicy is;
+	//   - Scan only accepts strings in the hyphenated 36-character UUID
+	//     form, rejecting the braced, URN and unhyphenated forms;
+	//   - Parse ignores LegacyLayout, rejecting strings it would normalize.
+	// Byte slices that are neither 16 raw bytes nor a 36-byte hyphenated
+	// UUID string, as accepted by ScanRaw, and payloads with characters
+	// outside Base58Alphabet are rejected in every mode. SetPrefix never
+	// validates; use SetValidPrefix where Strict must apply.
+	Strict bool
+}
+
+// forbidsSeparator reports whether prefixes may not contain Separator.
+func (c Config) forbidsSeparator() bool {
+	return c.SeparatorPolicy == SeparatorForbid || c.Strict
+}
+
+// LegacyLayout selects a string layout of older versions accepted by Parse.
+// Parsed XUIDs are normalized: their string form uses the current layout,
+// so stored strings can be rewritten lazily.
+type LegacyLayout int
+
+const (
+	// LegacyNone only accepts the current layout.
+	LegacyNone LegacyLayout = iota
+	// LegacyPrefixSuffix also accepts "payload_prefix", with the prefix
+	// after the payload, as produced by pre-1.0 forks. The payload ends at
+	// the first separator, so the prefix may contain separators.
+	LegacyPrefixSuffix
+)
+
+// SortableFallback selects what NewSortable does when creating a UUIDv7
+// fails.
+type SortableFallback int
+
+const (
+	// SortableFallbackNone returns the error.
+	SortableFallbackNone SortableFallback = iota
+	// SortableFallbackRandom returns a XUID holding a UUIDv4 instead, which
+	// IsRandom reports, so availability-critical paths do not fail a
+	// request because of a generation error. Such XUIDs do not sort by
+	// creation time.
+	SortableFallbackRandom
+)
+
+// Encoding selects the string form of XUIDs with a prefix.
+// XUIDs without a prefix are always encoded as the bare payload.
+type Encoding int
+
+const (
+	// EncodingDefault encodes XUIDs as "prefix_payload".
+	EncodingDefault Encoding = iota
+	// EncodingLengthPrefixed encodes XUIDs as "n:prefix_payload", where n is
+	// the length of the prefix in bytes. The prefix boundary is read from
+	// the marker instead of being inferred from the last separator, so any
+	// prefix round-trips. Parse only accepts strings with a marker, or
+	// without a prefix, while this encoding is selected.
+	EncodingLengthPrefixed
+)
+
+// SeparatorPolicy controls whether prefixes may contain Separator.
+type SeparatorPolicy int
+
+const (
+	// SeparatorAllow allows separators in prefixes. Parse splits strings at
+	// their last separator, which is unambiguous because the base58 alphabet
+	// does not contain it: "user_profile_<payload>" has prefix "user_profile".
+	SeparatorAllow SeparatorPolicy = iota
+	// SeparatorForbid makes constructors and Parse reject prefixes
+	// containing a separator.
+	SeparatorForbid
+)
+
+// SQLStorage selects how Value and Scan represent the UUID.
+type SQLStorage int
+
+const (
+	// SQLStorageDefault writes the hyphenated UUID string and scans strings
+	// or the 16 bytes in RFC 4122 order.
+	SQLStorageDefault SQLStorage = iota
+	// SQLStorageMSSQL writes and scans the 16 bytes in the mixed-endian
+	// layout of SQL Server uniqueidentifier columns, so IDs match those
+	// produced by NEWID() and NEWSEQUENTIALID(). Strings are still scanned
+	// as hyphenated UUIDs, which is how uniqueidentifier casts to text.
+	SQLStorageMSSQL
+	// SQLStorageBinary writes the 16 bytes in RFC 4122 order, for binary
+	// columns such as Oracle RAW(16) that do not convert UUID strings.
+	SQLStorageBinary
+)
+
+var config atomic.Pointer[Config]
+
+func init() {
+	config.Store(&Config{})
+}
+
+// GetConfig returns the current package configuration.
+func GetConfig() Config {
+	return *config.Load()
+}
+
+// SetConfig replaces the package configuration.
+func SetConfig(c Config) {
+	config.Store(&c)
+}
@@ -0,0 +1,345 @@
+package xuid_test
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withConfig applies c for the duration of the test.
+func withConfig(t *testing.T, c xuid.Config) {
+	t.Helper()
+	previous := xuid.GetConfig()
+	xuid.SetConfig(c)
+	t.Cleanup(func() { xuid.SetConfig(previous) })
+}
+
+func TestConfig(t *testing.T) {
+	t.Run("defaults to zero value", func(t *testing.T) {
+		assert.Equal(t, xuid.Config{}, xuid.GetConfig())
+	})
+
+	t.Run("SetConfig replaces configuration", func(t *testing.T) {
+		withConfig(t, xuid.Config{SeparatorPolicy: xuid.SeparatorForbid})
+
+		assert.Equal(t, xuid.SeparatorForbid, xuid.GetConfig().SeparatorPolicy)
+	})
+}
+
+func TestSeparatorPolicy(t *testing.T) {
+	t.Run("allows separators by default", func(t *testing.T) {
+		id, err := xuid.NewSortable("user_profile")
+		require.NoError(t, err)
+
+		parsed, err := xuid.Parse(id.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, "user_profile", parsed.GetPrefix())
+	})
+
+	t.Run("forbid rejects separators in constructors", func(t *testing.T) {
+		withConfig(t, xuid.Config{SeparatorPolicy: xuid.SeparatorForbid})
+
+		_, err := xuid.NewSortable("user_profile")
+		assert.ErrorIs(t, err, xuid.ErrPrefixSeparator)
+
+		_, err = xuid.NewWith(uuid.New(), "user_profile")
+		assert.ErrorIs(t, err, xuid.ErrPrefixSeparator)
+
+		_, err = xuid.NewSortable("user")
+		assert.NoError(t, err)
+	})
+
+	t.Run("forbid rejects separators in Parse", func(t *testing.T) {
+		s := xuid.MustNewSortable("user_profile").String()
+		withConfig(t, xuid.Config{SeparatorPolicy: xuid.SeparatorForbid})
+
+		_, err := xuid.Parse(s)
+
+		var perr *xuid.ParseError
+		require.True(t, errors.As(err, &perr))
+		assert.Equal(t, xuid.PartPrefix, perr.Part)
+		assert.Equal(t, 4, perr.Offset)
+		assert.Equal(t, '_', perr.Char)
+	})
+
+	t.Run("forbid accepts prefixes without separator", func(t *testing.T) {
+		s := xuid.MustNewSortable("user").String()
+		withConfig(t, xuid.Config{SeparatorPolicy: xuid.SeparatorForbid})
+
+		_, err := xuid.Parse(s)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestEncodingLengthPrefixed(t *testing.T) {
+	id := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	payload := xuid.Must(xuid.NewWith(id, "")).String()
+
+	t.Run("encodes prefix length", func(t *testing.T) {
+		withConfig(t, xuid.Config{Encoding: xuid.EncodingLengthPrefixed})
+
+		assert.Equal(t, "4:user_"+payload, xuid.Must(xuid.NewWith(id, "user")).String())
+		assert.Equal(t, payload, xuid.Must(xuid.NewWith(id, "")).String())
+	})
+
+	t.Run("round-trips exotic prefixes", func(t *testing.T) {
+		withConfig(t, xuid.Config{Encoding: xuid.EncodingLengthPrefixed})
+
+		for _, prefix := range []string{"user", "user_profile", "a:b", "12:_", "_", "x_" + payload} {
+			x := xuid.Must(xuid.NewWith(id, prefix))
+
+			parsed, err := xuid.Parse(x.String())
+
+			require.NoError(t, err, prefix)
+			assert.Equal(t, prefix, parsed.GetPrefix())
+			assert.Equal(t, id, parsed.GetUUID())
+		}
+	})
+
+	t.Run("parses strings without prefix", func(t *testing.T) {
+		withConfig(t, xuid.Config{Encoding: xuid.EncodingLengthPrefixed})
+
+		parsed, err := xuid.Parse(payload)
+
+		require.NoError(t, err)
+		assert.Empty(t, parsed.GetPrefix())
+	})
+
+	t.Run("rejects invalid markers", func(t *testing.T) {
+		withConfig(t, xuid.Config{Encoding: xuid.EncodingLengthPrefixed})
+
+		for _, s := range []string{
+			"user_" + payload,
+			"x:user_" + payload,
+			"04:user_" + payload,
+			"+4:user_" + payload,
+			"0:_" + payload,
+			"5:user_" + payload,
+			"99:user_" + payload,
+			":user_" + payload,
+		} {
+			_, err := xuid.Parse(s)
+
+			var perr *xuid.ParseError
+			require.True(t, errors.As(err, &perr), s)
+		}
+	})
+
+	t.Run("rejects long prefixes", func(t *testing.T) {
+		withConfig(t, xuid.Config{Encoding: xuid.EncodingLengthPrefixed})
+
+		_, err := xuid.Parse("129:" + payload)
+
+		var perr *xuid.ParseError
+		require.True(t, errors.As(err, &perr))
+		assert.Equal(t, "is too long", perr.Reason)
+	})
+
+	t.Run("StringLen includes marker", func(t *testing.T) {
+		withConfig(t, xuid.Config{Encoding: xuid.EncodingLengthPrefixed})
+
+		assert.Equal(t, len("4:user_")+xuid.EncodedLenMax, xuid.StringLen("user"))
+		assert.Equal(t, xuid.EncodedLenMax, xuid.StringLen(""))
+	})
+}
+
+func TestMaxFutureSkew(t *testing.T) {
+	future := xuid.Must(xuid.NewSortableAt("evt", time.Now().Add(time.Hour)))
+	present := xuid.MustNewSortable("evt")
+	random := xuid.MustNewRandom("evt")
+
+	t.Run("accepts any timestamp by default", func(t *testing.T) {
+		_, err := xuid.Parse(future.String())
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects timestamps too far in the future", func(t *testing.T) {
+		withConfig(t, xuid.Config{MaxFutureSkew: time.Minute})
+
+		_, err := xuid.Parse(future.String())
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+		var perr *xuid.ParseError
+		require.True(t, errors.As(err, &perr))
+		assert.Equal(t, xuid.PartPayload, perr.Part)
+	})
+
+	t.Run("accepts current and non-sortable XUIDs", func(t *testing.T) {
+		withConfig(t, xuid.Config{MaxFutureSkew: time.Minute})
+
+		_, err := xuid.Parse(present.String())
+		assert.NoError(t, err)
+		_, err = xuid.Parse(random.String())
+		assert.NoError(t, err)
+	})
+}
+
+// failOnceReader fails its first read and then reads from crypto/rand.
+type failOnceReader struct{ failed bool }
+
+func (r *failOnceReader) Read(p []byte) (int, error) {
+	if !r.failed {
+		r.failed = true
+		return 0, errors.New("entropy unavailable")
+	}
+	return rand.Read(p)
+}
+
+func TestSortableFallback(t *testing.T) {
+	withFailingRand := func(t *testing.T) {
+		uuid.SetRand(&failOnceReader{})
+		t.Cleanup(func() { uuid.SetRand(nil) })
+	}
+
+	t.Run("returns the error by default", func(t *testing.T) {
+		withFailingRand(t)
+
+		_, err := xuid.NewSortable("user")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("falls back to random XUIDs", func(t *testing.T) {
+		var errs []error
+		withConfig(t, xuid.Config{
+			SortableFallback:   xuid.SortableFallbackRandom,
+			OnSortableFallback: func(err error) { errs = append(errs, err) },
+		})
+		withFailingRand(t)
+
+		id, err := xuid.NewSortable("user")
+
+		require.NoError(t, err)
+		assert.True(t, id.IsRandom())
+		assert.Equal(t, "user", id.GetPrefix())
+		assert.Len(t, errs, 1)
+	})
+}
+
+func TestLegacyLayout(t *testing.T) {
+	id := xuid.MustNewSortable("user")
+	payload := strings.TrimPrefix(id.String(), "user_")
+
+	t.Run("rejects legacy strings by default", func(t *testing.T) {
+		_, err := xuid.Parse(payload + "_user")
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+
+	t.Run("normalizes legacy strings", func(t *testing.T) {
+		withConfig(t, xuid.Config{LegacyLayout: xuid.LegacyPrefixSuffix})
+
+		parsed, err := xuid.Parse(payload + "_user")
+
+		require.NoError(t, err)
+		assert.Equal(t, id, parsed)
+		assert.Equal(t, id.String(), parsed.String())
+	})
+
+	t.Run("keeps separators in legacy prefixes", func(t *testing.T) {
+		withConfig(t, xuid.Config{LegacyLayout: xuid.LegacyPrefixSuffix})
+
+		parsed, err := xuid.Parse(payload + "_user_profile")
+
+		require.NoError(t, err)
+		assert.Equal(t, "user_profile", parsed.GetPrefix())
+		assert.Equal(t, id.GetUUID(), parsed.GetUUID())
+	})
+
+	t.Run("prefers the current layout", func(t *testing.T) {
+		withConfig(t, xuid.Config{LegacyLayout: xuid.LegacyPrefixSuffix})
+
+		parsed, err := xuid.Parse(id.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, id, parsed)
+	})
+
+	t.Run("reports errors of the current layout", func(t *testing.T) {
+		withConfig(t, xuid.Config{LegacyLayout: xuid.LegacyPrefixSuffix})
+
+		_, err := xuid.Parse("user_0OIl")
+
+		var perr *xuid.ParseError
+		require.ErrorAs(t, err, &perr)
+		assert.Equal(t, xuid.PartPayload, perr.Part)
+	})
+}
+
+func TestStrict(t *testing.T) {
+	id := xuid.MustNewSortable("user")
+
+	t.Run("rejects separators in prefixes", func(t *testing.T) {
+		s := xuid.MustNewSortable("user_profile").String()
+		withConfig(t, xuid.Config{Strict: true})
+
+		_, err := xuid.NewSortable("user_profile")
+		assert.ErrorIs(t, err, xuid.ErrPrefixSeparator)
+
+		_, err = xuid.Parse(s)
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+
+	t.Run("scans only hyphenated UUID strings", func(t *testing.T) {
+		withConfig(t, xuid.Config{Strict: true})
+		u := id.GetUUID()
+
+		var x xuid.XUID
+		require.NoError(t, x.Scan(u.String()))
+		require.NoError(t, x.Scan(strings.ToUpper(u.String())))
+		assert.Equal(t, u, x.GetUUID())
+
+		for _, s := range []string{"{" + u.String() + "}", u.URN(), strings.ReplaceAll(u.String(), "-", "")} {
+			assert.Error(t, x.Scan(s), s)
+		}
+	})
+
+	t.Run("accepts other UUID strings by default", func(t *testing.T) {
+		u := id.GetUUID()
+
+		var x xuid.XUID
+		require.NoError(t, x.Scan(strings.ReplaceAll(u.String(), "-", "")))
+
+		assert.Equal(t, u, x.GetUUID())
+	})
+
+	t.Run("rejects legacy layouts", func(t *testing.T) {
+		payload := strings.TrimPrefix(id.String(), "user_")
+		withConfig(t, xuid.Config{Strict: true, LegacyLayout: xuid.LegacyPrefixSuffix})
+
+		_, err := xuid.Parse(payload + "_user")
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+
+	t.Run("validates prefixes with SetValidPrefix", func(t *testing.T) {
+		withConfig(t, xuid.Config{Strict: true})
+		x := id
+
+		assert.ErrorIs(t, x.SetValidPrefix("a_b"), xuid.ErrPrefixSeparator)
+		assert.Equal(t, id, x)
+
+		require.NoError(t, x.SetValidPrefix("account"))
+		assert.Equal(t, "account", x.GetPrefix())
+	})
+
+	t.Run("keeps rejecting short byte slices and invalid characters", func(t *testing.T) {
+		withConfig(t, xuid.Config{Strict: true})
+		u := id.GetUUID()
+
+		var x xuid.XUID
+		assert.Error(t, x.Scan(u[:15]))
+		_, err := xuid.Parse("user_0OIl")
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+}
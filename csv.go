@@ -0,0 +1,86 @@
+package xuid
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// MarshalCSV implements the gocsv TypeMarshaller interface.
+// Empty XUIDs are written as empty cells.
+func (x XUID) MarshalCSV() (string, error) {
+	if IsEmpty(x) {
+		return "", nil
+	}
+	return x.String(), nil
+}
+
+// UnmarshalCSV implements the gocsv TypeUnmarshaller interface.
+// Empty cells are read as empty XUIDs.
+func (x *XUID) UnmarshalCSV(value string) error {
+	if value == "" {
+		x.uuid = uuid.Nil
+		x.prefix = ""
+		return nil
+	}
+	return x.UnmarshalText([]byte(value))
+}
+
+// ReadCSVColumn reads the column with the given header from CSV data and
+// parses every value in it. Empty cells are skipped.
+func ReadCSVColumn(r io.Reader, header string) ([]XUID, error) {
+	cr := csv.NewReader(r)
+	head, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	col := -1
+	for i, h := range head {
+		if h == header {
+			col = i
+			break
+		}
+	}
+	if col < 0 {
+		return nil, fmt.Errorf("csv column %q not found", header)
+	}
+
+	var res []XUID
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if col >= len(record) || record[col] == "" {
+			continue
+		}
+		xid, err := Parse(record[col])
+		if err != nil {
+			line, _ := cr.FieldPos(col)
+			return nil, fmt.Errorf("csv line %d: %w", line, err)
+		}
+		res = append(res, xid)
+	}
+}
+
+// WriteCSVColumn writes ids as a single CSV column with the given header.
+func WriteCSVColumn(w io.Writer, header string, ids []XUID) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{header}); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		v, _ := id.MarshalCSV()
+		if err := cw.Write([]string{v}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
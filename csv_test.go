@@ -0,0 +1,99 @@
+package xuid_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVMarshaling(t *testing.T) {
+	t.Run("marshals XUID to its string form", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+
+		value, err := id.MarshalCSV()
+
+		require.NoError(t, err)
+		assert.Equal(t, id.String(), value)
+	})
+
+	t.Run("marshals empty XUID to empty cell", func(t *testing.T) {
+		id, _ := xuid.NilUUID()
+
+		value, err := id.MarshalCSV()
+
+		require.NoError(t, err)
+		assert.Equal(t, "", value)
+	})
+
+	t.Run("unmarshals cell to XUID", func(t *testing.T) {
+		original := xuid.MustNewSortable("user")
+
+		var parsed xuid.XUID
+		err := parsed.UnmarshalCSV(original.String())
+
+		require.NoError(t, err)
+		assert.True(t, original.Equal(parsed))
+	})
+
+	t.Run("unmarshals empty cell to empty XUID", func(t *testing.T) {
+		parsed := xuid.MustNewSortable("user")
+
+		err := parsed.UnmarshalCSV("")
+
+		require.NoError(t, err)
+		assert.True(t, xuid.IsEmpty(parsed))
+		assert.Equal(t, "", parsed.GetPrefix())
+	})
+
+	t.Run("returns error for invalid cell", func(t *testing.T) {
+		var parsed xuid.XUID
+
+		assert.ErrorIs(t, parsed.UnmarshalCSV("invalid_string"), xuid.ErrParse)
+	})
+}
+
+func TestCSVColumns(t *testing.T) {
+	t.Run("round trips a column", func(t *testing.T) {
+		ids := []xuid.XUID{xuid.MustNewSortable("user"), xuid.MustNewSortable("user")}
+		var buf bytes.Buffer
+
+		err := xuid.WriteCSVColumn(&buf, "id", ids)
+		require.NoError(t, err)
+
+		parsed, err := xuid.ReadCSVColumn(&buf, "id")
+		require.NoError(t, err)
+		require.Len(t, parsed, 2)
+		assert.True(t, ids[0].Equal(parsed[0]))
+		assert.True(t, ids[1].Equal(parsed[1]))
+	})
+
+	t.Run("reads column by header and skips empty cells", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+		data := "name,id\nalice," + id.String() + "\nbob,\n"
+
+		parsed, err := xuid.ReadCSVColumn(strings.NewReader(data), "id")
+
+		require.NoError(t, err)
+		require.Len(t, parsed, 1)
+		assert.True(t, id.Equal(parsed[0]))
+	})
+
+	t.Run("returns error for missing column", func(t *testing.T) {
+		_, err := xuid.ReadCSVColumn(strings.NewReader("name\nalice\n"), "id")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("returns error with line for invalid value", func(t *testing.T) {
+		data := "id\n" + xuid.MustNewSortable("user").String() + "\ninvalid_value\n"
+
+		_, err := xuid.ReadCSVColumn(strings.NewReader(data), "id")
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+		assert.Contains(t, err.Error(), "csv line 3")
+	})
+}
@@ -0,0 +1,67 @@
+package xuid
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Dialect identifies a SQL database dialect.
+type Dialect string
+
+const (
+	// DialectPostgres stores the UUID in a native uuid column.
+	DialectPostgres Dialect = "postgres"
+	// DialectMySQL stores the UUID in a BINARY(16) column. Values written
+	// by Value must be converted with UUID_TO_BIN; Scan reads the raw bytes.
+	DialectMySQL Dialect = "mysql"
+	// DialectSQLite stores the UUID string produced by Value in a TEXT column.
+	DialectSQLite Dialect = "sqlite"
+	// DialectMSSQL stores the UUID in a uniqueidentifier column. Set
+	// Config.SQLStorage to SQLStorageMSSQL so Value and Scan use its byte
+	// order.
+	DialectMSSQL Dialect = "mssql"
+	// DialectOracle stores the UUID in a RAW(16) column. Set
+	// Config.SQLStorage to SQLStorageBinary so Value writes raw bytes.
+	DialectOracle Dialect = "oracle"
+	// DialectPostgresText stores the full XUID string, prefix included, in
+	// a varchar column validated by a regular expression. Write x.String()
+	// to such columns, since Value does not include the prefix.
+	DialectPostgresText Dialect = "postgres-text"
+	// DialectMySQLText is the MySQL equivalent of DialectPostgresText.
+	DialectMySQLText Dialect = "mysql-text"
+)
+
+// DDL returns the recommended column definition, including CHECK
+// constraints, for storing XUIDs in column.
+// prefixOptional only affects text dialects, which store the prefix: when
+// false the constraint requires every value to carry a prefix.
+func DDL(dialect Dialect, column string, prefixOptional bool) (string, error) {
+	switch dialect {
+	case DialectPostgres:
+		return column + " uuid", nil
+	case DialectMySQL:
+		return column + " BINARY(16)", nil
+	case DialectMSSQL:
+		return column + " UNIQUEIDENTIFIER", nil
+	case DialectOracle:
+		return column + " RAW(16)", nil
+	case DialectSQLite:
+		return fmt.Sprintf("%s TEXT CHECK (length(%s) = 36)", column, column), nil
+	case DialectPostgresText:
+		return fmt.Sprintf("%s varchar(%d) CHECK (%s ~ '%s')", column, MaxStringLen, column, Pattern(prefixOptional)), nil
+	case DialectMySQLText:
+		return fmt.Sprintf("%s VARCHAR(%d) CHECK (REGEXP_LIKE(%s, '%s', 'c'))", column, MaxStringLen, column, Pattern(prefixOptional)), nil
+	}
+	return "", fmt.Errorf("unsupported SQL dialect %q", dialect)
+}
+
+// Pattern returns a POSIX regular expression matching XUID strings.
+// When prefixOptional is false, the expression only matches strings with
+// a prefix.
+func Pattern(prefixOptional bool) string {
+	prefix := "^.{1," + strconv.Itoa(MaxPrefixLen) + "}" + Separator
+	if prefixOptional {
+		prefix = "^(.{1," + strconv.Itoa(MaxPrefixLen) + "}" + Separator + ")?"
+	}
+	return prefix + "[1-9A-HJ-NP-Za-km-z]{" + strconv.Itoa(EncodedLenMin) + "," + strconv.Itoa(EncodedLenMax) + "}$"
+}
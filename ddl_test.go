@@ -0,0 +1,71 @@
+package xuid_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDDL(t *testing.T) {
+	t.Run("emits native column types", func(t *testing.T) {
+		pg, err := xuid.DDL(xuid.DialectPostgres, "id", false)
+		require.NoError(t, err)
+		assert.Equal(t, "id uuid", pg)
+
+		my, err := xuid.DDL(xuid.DialectMySQL, "id", false)
+		require.NoError(t, err)
+		assert.Equal(t, "id BINARY(16)", my)
+
+		ms, err := xuid.DDL(xuid.DialectMSSQL, "id", false)
+		require.NoError(t, err)
+		assert.Equal(t, "id UNIQUEIDENTIFIER", ms)
+
+		ora, err := xuid.DDL(xuid.DialectOracle, "id", false)
+		require.NoError(t, err)
+		assert.Equal(t, "id RAW(16)", ora)
+
+		lite, err := xuid.DDL(xuid.DialectSQLite, "id", false)
+		require.NoError(t, err)
+		assert.Equal(t, "id TEXT CHECK (length(id) = 36)", lite)
+	})
+
+	t.Run("emits text columns with regex check", func(t *testing.T) {
+		pg, err := xuid.DDL(xuid.DialectPostgresText, "id", true)
+		require.NoError(t, err)
+		assert.Equal(t, "id varchar(151) CHECK (id ~ '"+xuid.Pattern(true)+"')", pg)
+
+		my, err := xuid.DDL(xuid.DialectMySQLText, "id", false)
+		require.NoError(t, err)
+		assert.Equal(t, "id VARCHAR(151) CHECK (REGEXP_LIKE(id, '"+xuid.Pattern(false)+"', 'c'))", my)
+	})
+
+	t.Run("returns error for unknown dialect", func(t *testing.T) {
+		_, err := xuid.DDL("db2", "id", false)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPattern(t *testing.T) {
+	withPrefix := xuid.MustNewSortable("user_profile").String()
+	withoutPrefix := xuid.MustNewSortable("").String()
+
+	t.Run("requires prefix", func(t *testing.T) {
+		re := regexp.MustCompile(xuid.Pattern(false))
+
+		assert.True(t, re.MatchString(withPrefix))
+		assert.False(t, re.MatchString(withoutPrefix))
+		assert.False(t, re.MatchString("user_0OIl0OIl0OIl0OIl0OIl"))
+	})
+
+	t.Run("allows missing prefix", func(t *testing.T) {
+		re := regexp.MustCompile(xuid.Pattern(true))
+
+		assert.True(t, re.MatchString(withPrefix))
+		assert.True(t, re.MatchString(withoutPrefix))
+		assert.False(t, re.MatchString("short"))
+	})
+}
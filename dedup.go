@@ -0,0 +1,73 @@
+package xuid
+
+import (
+	"math"
+	"sync"
+)
+
+// dedupHashes is the number of hash functions of Deduper filters, which
+// gives a false positive rate of about 1% per filter at capacity.
+const dedupHashes = 7
+
+// Deduper detects repeated XUIDs in a stream, such as event IDs delivered
+// at least once, in bounded memory. It keeps two bloom filters of capacity
+// XUIDs each: when the current filter is full, it replaces the previous
+// one and a new filter is started. Every XUID among the last capacity seen
+// is remembered; older XUIDs are forgotten.
+//
+// Like any bloom filter, a Deduper may report a new XUID as seen, with a
+// probability of about 2% at capacity, but never misses a recent repeat.
+// A Deduper is safe for concurrent use.
+type Deduper struct {
+	mu       sync.Mutex
+	capacity int
+	n        int
+	cur      []uint64
+	prev     []uint64
+}
+
+// NewDeduper returns a Deduper remembering at least the last capacity
+// XUIDs. It uses about 2.4 bytes per XUID of capacity. Capacities below 1
+// are treated as 1.
+func NewDeduper(capacity int) *Deduper {
+	capacity = max(capacity, 1)
+	return &Deduper{capacity: capacity, cur: newDedupFilter(capacity), prev: newDedupFilter(capacity)}
+}
+
+// Seen reports whether x was probably seen recently, and records it.
+func (d *Deduper) Seen(x XUID) bool {
+	hs := x.Fingerprint(dedupHashes)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if dedupHas(d.cur, hs) {
+		return true
+	}
+	seen := dedupHas(d.prev, hs)
+	if d.n == d.capacity {
+		d.prev, d.cur = d.cur, d.prev
+		clear(d.cur)
+		d.n = 0
+	}
+	for _, h := range hs {
+		i := h % uint64(len(d.cur)*64)
+		d.cur[i/64] |= 1 << (i % 64)
+	}
+	d.n++
+	return seen
+}
+
+func newDedupFilter(capacity int) []uint64 {
+	bits := math.Ceil(float64(capacity) * -math.Log(0.01) / (math.Ln2 * math.Ln2))
+	return make([]uint64, (int(bits)+63)/64)
+}
+
+func dedupHas(filter []uint64, hs []uint64) bool {
+	for _, h := range hs {
+		i := h % uint64(len(filter)*64)
+		if filter[i/64]&(1<<(i%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduper(t *testing.T) {
+	t.Run("reports repeated XUIDs", func(t *testing.T) {
+		d := xuid.NewDeduper(100)
+		x := xuid.MustNewSortable("evt")
+
+		assert.False(t, d.Seen(x))
+		assert.True(t, d.Seen(x))
+	})
+
+	t.Run("remembers the last capacity XUIDs", func(t *testing.T) {
+		d := xuid.NewDeduper(1000)
+		ids := make([]xuid.XUID, 5000)
+		for i := range ids {
+			ids[i] = xuid.MustNewSortable("evt")
+			d.Seen(ids[i])
+		}
+
+		for _, x := range ids[len(ids)-1000:] {
+			assert.True(t, d.Seen(x))
+		}
+	})
+
+	t.Run("has a low false positive rate", func(t *testing.T) {
+		d := xuid.NewDeduper(1000)
+		falsePositives := 0
+		for i := 0; i < 10000; i++ {
+			if d.Seen(xuid.MustNewSortable("evt")) {
+				falsePositives++
+			}
+		}
+
+		assert.Less(t, falsePositives, 300)
+	})
+
+	t.Run("forgets old XUIDs", func(t *testing.T) {
+		d := xuid.NewDeduper(100)
+		old := make([]xuid.XUID, 50)
+		for i := range old {
+			old[i] = xuid.MustNewSortable("evt")
+			d.Seen(old[i])
+		}
+		for i := 0; i < 1000; i++ {
+			d.Seen(xuid.MustNewSortable("evt"))
+		}
+
+		remembered := 0
+		for _, x := range old {
+			if d.Seen(x) {
+				remembered++
+			}
+		}
+		assert.Less(t, remembered, 10)
+	})
+}
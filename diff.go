@@ -0,0 +1,66 @@
+package xuid
+
+import "slices"
+
+// Diff compares two snapshots of an ID list, such as remote and local
+// lists reconciled by a sync engine, and returns the XUIDs of next that are
+// not in prev, the XUIDs of prev that are not in next, and the XUIDs of
+// next that are also in prev. Each result keeps the order of the list its
+// XUIDs come from. XUIDs are compared by their bytes in O(n log n) time,
+// without converting them to strings.
+func Diff(prev, next []XUID) (added, removed, kept []XUID) {
+	sortedPrev, sortedNext := sortedClone(prev), sortedClone(next)
+	for _, x := range next {
+		if _, ok := SearchSorted(sortedPrev, x); ok {
+			kept = append(kept, x)
+		} else {
+			added = append(added, x)
+		}
+	}
+	for _, x := range prev {
+		if _, ok := SearchSorted(sortedNext, x); !ok {
+			removed = append(removed, x)
+		}
+	}
+	return added, removed, kept
+}
+
+// Added returns the XUIDs of next that are not in prev, as Diff does.
+func Added(prev, next []XUID) []XUID {
+	return missing(next, prev)
+}
+
+// Removed returns the XUIDs of prev that are not in next, as Diff does.
+func Removed(prev, next []XUID) []XUID {
+	return missing(prev, next)
+}
+
+// Kept returns the XUIDs of next that are also in prev, as Diff does.
+func Kept(prev, next []XUID) []XUID {
+	sorted := sortedClone(prev)
+	var res []XUID
+	for _, x := range next {
+		if _, ok := SearchSorted(sorted, x); ok {
+			res = append(res, x)
+		}
+	}
+	return res
+}
+
+// missing returns the XUIDs of ids that are not in other.
+func missing(ids, other []XUID) []XUID {
+	sorted := sortedClone(other)
+	var res []XUID
+	for _, x := range ids {
+		if _, ok := SearchSorted(sorted, x); !ok {
+			res = append(res, x)
+		}
+	}
+	return res
+}
+
+func sortedClone(ids []XUID) []XUID {
+	res := slices.Clone(ids)
+	Sort(res)
+	return res
+}
@@ -0,0 +1,56 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	a, b, c, d := xuid.MustNewRandom("doc"), xuid.MustNewRandom("doc"), xuid.MustNewRandom("doc"), xuid.MustNewRandom("doc")
+	prev := []xuid.XUID{a, b, c}
+	next := []xuid.XUID{d, c, a}
+
+	t.Run("splits added, removed and kept XUIDs", func(t *testing.T) {
+		added, removed, kept := xuid.Diff(prev, next)
+
+		assert.Equal(t, []xuid.XUID{d}, added)
+		assert.Equal(t, []xuid.XUID{b}, removed)
+		assert.Equal(t, []xuid.XUID{c, a}, kept)
+	})
+
+	t.Run("matches the single results", func(t *testing.T) {
+		added, removed, kept := xuid.Diff(prev, next)
+
+		assert.Equal(t, added, xuid.Added(prev, next))
+		assert.Equal(t, removed, xuid.Removed(prev, next))
+		assert.Equal(t, kept, xuid.Kept(prev, next))
+	})
+
+	t.Run("distinguishes prefixes", func(t *testing.T) {
+		other := xuid.Must(xuid.NewWith(a.GetUUID(), "file"))
+
+		added, removed, kept := xuid.Diff([]xuid.XUID{a}, []xuid.XUID{other})
+
+		assert.Equal(t, []xuid.XUID{other}, added)
+		assert.Equal(t, []xuid.XUID{a}, removed)
+		assert.Empty(t, kept)
+	})
+
+	t.Run("does not modify its arguments", func(t *testing.T) {
+		before := append([]xuid.XUID(nil), next...)
+
+		xuid.Diff(prev, next)
+
+		assert.Equal(t, before, next)
+	})
+
+	t.Run("handles empty lists", func(t *testing.T) {
+		added, removed, kept := xuid.Diff(nil, prev)
+
+		assert.Equal(t, prev, added)
+		assert.Empty(t, removed)
+		assert.Empty(t, kept)
+	})
+}
@@ -0,0 +1,43 @@
+package xuid
+
+import "strings"
+
+// DisplayGroupLen is the number of payload characters per group in
+// DisplayString.
+const DisplayGroupLen = 4
+
+// DisplayString returns the string form of x with the payload split into
+// groups of DisplayGroupLen characters separated by hyphens, such as
+// "user_8M7Q-q2vR-3kGb-F9wN-5pL2-xA", for invoices and support screens
+// where IDs are read aloud or copied by hand. ParseDisplay parses it back.
+func (x XUID) DisplayString() string {
+	s := x.String()
+	start := strings.LastIndex(s, Separator) + 1
+	payload := s[start:]
+	var b strings.Builder
+	b.Grow(len(s) + len(payload)/DisplayGroupLen)
+	b.WriteString(s[:start])
+	for i := 0; i < len(payload); i += DisplayGroupLen {
+		if i > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(payload[i:min(i+DisplayGroupLen, len(payload))])
+	}
+	return b.String()
+}
+
+// ParseDisplay parses a XUID string formatted by DisplayString, or
+// regrouped by a human: hyphens and spaces in the payload are ignored,
+// whatever their position, and the string is cleaned up as by
+// ParseLenient. Plain XUID strings are accepted too.
+func ParseDisplay(s string) (XUID, error) {
+	s = cleanup(s)
+	start := strings.LastIndex(s, Separator) + 1
+	payload := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, s[start:])
+	return Parse(s[:start] + payload)
+}
@@ -0,0 +1,54 @@
+package xuid_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplayString(t *testing.T) {
+	id := xuid.Must(xuid.NewWith(uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057"), "user"))
+	payload := strings.TrimPrefix(id.String(), "user_")
+
+	t.Run("groups the payload", func(t *testing.T) {
+		want := "user_" + payload[0:4] + "-" + payload[4:8] + "-" + payload[8:12] + "-" + payload[12:16] + "-" + payload[16:20] + "-" + payload[20:]
+
+		assert.Equal(t, want, id.DisplayString())
+	})
+
+	t.Run("groups XUIDs without prefix", func(t *testing.T) {
+		bare := xuid.Must(xuid.NewWith(id.GetUUID(), ""))
+
+		assert.Equal(t, strings.TrimPrefix(id.DisplayString(), "user_"), bare.DisplayString())
+	})
+
+	t.Run("round-trips through ParseDisplay", func(t *testing.T) {
+		parsed, err := xuid.ParseDisplay(id.DisplayString())
+
+		require.NoError(t, err)
+		assert.Equal(t, id, parsed)
+	})
+}
+
+func TestParseDisplay(t *testing.T) {
+	id := xuid.Must(xuid.NewWith(uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057"), "my-team"))
+	payload := strings.TrimPrefix(id.String(), "my-team_")
+
+	for _, s := range []string{
+		id.String(),
+		" " + id.DisplayString() + "\n",
+		"my-team_" + payload[:3] + " " + payload[3:10] + "-" + payload[10:],
+	} {
+		parsed, err := xuid.ParseDisplay(s)
+
+		require.NoError(t, err, s)
+		assert.Equal(t, id, parsed, s)
+	}
+
+	_, err := xuid.ParseDisplay("my-team_0OIl-abcd")
+	assert.ErrorIs(t, err, xuid.ErrParse)
+}
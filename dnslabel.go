@@ -0,0 +1,47 @@
+package xuid
+
+import (
+	"encoding/base32"
+	"strings"
+)
+
+// dnsLabelLen is the maximum length of an RFC 1123 label.
+const dnsLabelLen = 63
+
+var dnsEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// DNSLabel returns an RFC 1123 label, such as "user-aa..." suitable for
+// naming Kubernetes pods and jobs after the XUID. The UUID is re-encoded as
+// 26 lowercase base32 characters, since base58 is case-sensitive.
+// The prefix is lowercased, runs of other characters than letters and
+// digits become a single hyphen, and it is truncated to fit 63 characters.
+// Distinct UUIDs always yield distinct labels, but the prefix cannot be
+// recovered from the label.
+func (x XUID) DNSLabel() string {
+	payload := dnsEncoding.EncodeToString(x.uuid[:])
+	prefix := dnsLabelPrefix(x.prefix, dnsLabelLen-len(payload)-1)
+	if prefix == "" {
+		return payload
+	}
+	return prefix + "-" + payload
+}
+
+func dnsLabelPrefix(s string, maxLen int) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(s) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(r)
+		} else {
+			hyphen = true
+		}
+		if b.Len() >= maxLen {
+			break
+		}
+	}
+	return strings.TrimSuffix(b.String()[:min(b.Len(), maxLen)], "-")
+}
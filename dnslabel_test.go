@@ -0,0 +1,67 @@
+package xuid_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var dnsLabelPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+func TestDNSLabel(t *testing.T) {
+	id := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+
+	t.Run("joins prefix and base32 payload", func(t *testing.T) {
+		x, err := xuid.NewWith(id, "user")
+		require.NoError(t, err)
+
+		assert.Equal(t, "user-agequxnmsz3uxpgowmbatguak4", x.DNSLabel())
+	})
+
+	t.Run("omits empty prefix", func(t *testing.T) {
+		x, err := xuid.NewWith(id, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, "agequxnmsz3uxpgowmbatguak4", x.DNSLabel())
+	})
+
+	t.Run("produces valid labels for any prefix", func(t *testing.T) {
+		for _, prefix := range []string{
+			"User_Profile",
+			"__user__",
+			"a..b",
+			"---",
+			"café",
+			strings.Repeat("a", xuid.MaxPrefixLen),
+			strings.Repeat("ab_", 20),
+		} {
+			x, err := xuid.NewWith(id, prefix)
+			require.NoError(t, err)
+
+			label := x.DNSLabel()
+
+			assert.Regexp(t, dnsLabelPattern, label, prefix)
+			assert.LessOrEqual(t, len(label), 63, prefix)
+		}
+	})
+
+	t.Run("sanitizes prefix", func(t *testing.T) {
+		x, err := xuid.NewWith(id, "User__Profile")
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(x.DNSLabel(), "user-profile-"))
+	})
+
+	t.Run("distinct UUIDs yield distinct labels", func(t *testing.T) {
+		long := strings.Repeat("p", xuid.MaxPrefixLen)
+		a := xuid.Must(xuid.NewRandom(long))
+		b := xuid.Must(xuid.NewRandom(long))
+
+		assert.NotEqual(t, a.DNSLabel(), b.DNSLabel())
+	})
+}
@@ -0,0 +1,127 @@
+package xuid
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PrefixTransition describes a prefix rename in progress, such as "proj"
+// becoming "workspace", during which both prefixes are accepted.
+type PrefixTransition struct {
+	// Old and New are the prefixes before and after the rename.
+	Old string
+	New string
+	// Until ends the transition window: XUIDs with the old prefix are
+	// rejected from then on. The zero value accepts them indefinitely.
+	Until time.Time
+	// OnOld, if set, is called with every XUID parsed with the old prefix,
+	// so rollouts can track which clients still send it.
+	OnOld func(x XUID)
+}
+
+// Transition is implemented by the empty struct types naming the
+// PrefixTransition of a DualPrefix type.
+type Transition interface {
+	Transition() *PrefixTransition
+}
+
+// DualPrefix is a XUID whose prefix is being renamed as described by T:
+//
+//	var workspaceRename = &xuid.PrefixTransition{Old: "proj", New: "workspace"}
+//
+//	type WorkspaceRename struct{}
+//
+//	func (WorkspaceRename) Transition() *xuid.PrefixTransition { return workspaceRename }
+//
+//	type WorkspaceID = xuid.DualPrefix[WorkspaceRename]
+//
+// Parsing and unmarshaling accept both prefixes during the transition
+// window and normalize the old one, so the embedded XUID, and every
+// encoding of it, always carries the new prefix. Create DualPrefix values
+// with NewDualPrefix to keep that guarantee.
+type DualPrefix[T Transition] struct {
+	XUID
+}
+
+// NewDualPrefix returns x as a DualPrefix, renaming its prefix if it is the
+// old one. Unlike parsing, it ignores the transition window.
+func NewDualPrefix[T Transition](x XUID) DualPrefix[T] {
+	var t T
+	if tr := t.Transition(); x.prefix == tr.Old {
+		x.prefix = tr.New
+	}
+	return DualPrefix[T]{XUID: x}
+}
+
+// ParseDualPrefix parses idstr, which must have the new prefix of T, or the
+// old one during the transition window. Other prefixes fail with
+// ErrPrefixMismatch.
+func ParseDualPrefix[T Transition](idstr string) (DualPrefix[T], error) {
+	var id DualPrefix[T]
+	return id, id.parse(idstr)
+}
+
+// parse parses idstr into id, which is left unchanged when parsing fails.
+func (id *DualPrefix[T]) parse(idstr string) error {
+	var x XUID
+	if err := ParseInto(&x, idstr); err != nil {
+		return err
+	}
+	var t T
+	tr := t.Transition()
+	switch {
+	case x.prefix == tr.New:
+	case x.prefix == tr.Old && (tr.Until.IsZero() || time.Now().Before(tr.Until)):
+		if tr.OnOld != nil {
+			tr.OnOld(x)
+		}
+		x.prefix = tr.New
+	default:
+		return fmt.Errorf("%w: got %q, want %q", ErrPrefixMismatch, x.prefix, tr.New)
+	}
+	id.XUID = x
+	return nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (id *DualPrefix[T]) UnmarshalText(data []byte) error {
+	return id.parse(string(data))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (id *DualPrefix[T]) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return id.parse(s)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (id *DualPrefix[T]) UnmarshalBinary(data []byte) error {
+	return id.parse(string(data))
+}
+
+// UnmarshalCSV implements the gocsv TypeUnmarshaller interface.
+// Empty cells are read as empty XUIDs.
+func (id *DualPrefix[T]) UnmarshalCSV(value string) error {
+	if value == "" {
+		id.XUID = XUID{}
+		return nil
+	}
+	return id.parse(value)
+}
+
+// Scan implements the sql.Scanner interface. Stored UUIDs carry no prefix,
+// so the new prefix is set on non-nil XUIDs.
+func (id *DualPrefix[T]) Scan(value interface{}) error {
+	if err := id.XUID.Scan(value); err != nil {
+		return err
+	}
+	if !IsEmpty(id.XUID) {
+		var t T
+		id.prefix = t.Transition().New
+	}
+	return nil
+}
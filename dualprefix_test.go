@@ -0,0 +1,94 @@
+package xuid_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var workspaceRename = &xuid.PrefixTransition{Old: "proj", New: "workspace"}
+
+type workspaceTransition struct{}
+
+func (workspaceTransition) Transition() *xuid.PrefixTransition { return workspaceRename }
+
+type workspaceID = xuid.DualPrefix[workspaceTransition]
+
+func withTransition(t *testing.T, tr xuid.PrefixTransition) {
+	t.Helper()
+	prev := *workspaceRename
+	*workspaceRename = tr
+	t.Cleanup(func() { *workspaceRename = prev })
+}
+
+func TestDualPrefix(t *testing.T) {
+	proj := xuid.MustNewSortable("proj")
+	workspace := proj
+	workspace.SetPrefix("workspace")
+
+	t.Run("accepts the old prefix and reports it", func(t *testing.T) {
+		var seen []xuid.XUID
+		withTransition(t, xuid.PrefixTransition{Old: "proj", New: "workspace", OnOld: func(x xuid.XUID) { seen = append(seen, x) }})
+
+		id, err := xuid.ParseDualPrefix[workspaceTransition](proj.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, workspace, id.XUID)
+		assert.Equal(t, []xuid.XUID{proj}, seen)
+	})
+
+	t.Run("accepts the new prefix without reporting it", func(t *testing.T) {
+		var seen []xuid.XUID
+		withTransition(t, xuid.PrefixTransition{Old: "proj", New: "workspace", OnOld: func(x xuid.XUID) { seen = append(seen, x) }})
+
+		id, err := xuid.ParseDualPrefix[workspaceTransition](workspace.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, workspace, id.XUID)
+		assert.Empty(t, seen)
+	})
+
+	t.Run("rejects the old prefix after the window", func(t *testing.T) {
+		withTransition(t, xuid.PrefixTransition{Old: "proj", New: "workspace", Until: time.Now().Add(-time.Minute)})
+
+		_, err := xuid.ParseDualPrefix[workspaceTransition](proj.String())
+
+		assert.ErrorIs(t, err, xuid.ErrPrefixMismatch)
+	})
+
+	t.Run("rejects other prefixes", func(t *testing.T) {
+		_, err := xuid.ParseDualPrefix[workspaceTransition](xuid.MustNewSortable("user").String())
+
+		assert.ErrorIs(t, err, xuid.ErrPrefixMismatch)
+	})
+
+	t.Run("marshals JSON with the new prefix", func(t *testing.T) {
+		var v struct {
+			ID workspaceID `json:"id"`
+		}
+
+		require.NoError(t, json.Unmarshal([]byte(`{"id":"`+proj.String()+`"}`), &v))
+		data, err := json.Marshal(v)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id":"`+workspace.String()+`"}`, string(data))
+	})
+
+	t.Run("converts XUIDs with the old prefix", func(t *testing.T) {
+		id := xuid.NewDualPrefix[workspaceTransition](proj)
+
+		assert.Equal(t, workspace.String(), id.String())
+	})
+
+	t.Run("sets the new prefix on Scan", func(t *testing.T) {
+		var id workspaceID
+
+		require.NoError(t, id.Scan(proj.GetUUID().String()))
+
+		assert.Equal(t, workspace, id.XUID)
+	})
+}
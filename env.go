@@ -0,0 +1,26 @@
+package xuid
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FromEnv parses the XUID in the environment variable key, ignoring
+// surrounding whitespace. An unset or empty variable yields a nil XUID, or
+// fails with ErrMissingXUID if required is true. Parse errors name the
+// variable.
+func FromEnv(key string, required bool) (XUID, error) {
+	s := strings.TrimSpace(os.Getenv(key))
+	if s == "" {
+		if required {
+			return XUID{}, fmt.Errorf("%w: environment variable %s is not set", ErrMissingXUID, key)
+		}
+		return XUID{}, nil
+	}
+	x, err := Parse(s)
+	if err != nil {
+		return XUID{}, fmt.Errorf("environment variable %s: %w", key, err)
+	}
+	return x, nil
+}
@@ -0,0 +1,47 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromEnv(t *testing.T) {
+	id := xuid.MustNewSortable("tenant")
+
+	t.Run("parses the variable", func(t *testing.T) {
+		t.Setenv("XUID_TEST_TENANT", " "+id.String()+"\n")
+
+		x, err := xuid.FromEnv("XUID_TEST_TENANT", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, id, x)
+	})
+
+	t.Run("returns a nil XUID for optional unset variables", func(t *testing.T) {
+		x, err := xuid.FromEnv("XUID_TEST_UNSET", false)
+
+		require.NoError(t, err)
+		assert.True(t, xuid.IsEmpty(x))
+	})
+
+	t.Run("rejects required unset variables", func(t *testing.T) {
+		t.Setenv("XUID_TEST_EMPTY", "")
+
+		_, err := xuid.FromEnv("XUID_TEST_EMPTY", true)
+
+		assert.ErrorIs(t, err, xuid.ErrMissingXUID)
+		assert.Contains(t, err.Error(), "XUID_TEST_EMPTY")
+	})
+
+	t.Run("names the variable in parse errors", func(t *testing.T) {
+		t.Setenv("XUID_TEST_INVALID", "tenant_0OIl")
+
+		_, err := xuid.FromEnv("XUID_TEST_INVALID", false)
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+		assert.Contains(t, err.Error(), "XUID_TEST_INVALID")
+	})
+}
@@ -1,8 +1,82 @@
 package xuid
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrInvalidUUIDString = errors.New("UUID string is invalid")
 	ErrParse             = errors.New("XUID string cannot be parsed")
+	ErrPrefixTooLong     = errors.New("XUID prefix is longer than MaxPrefixLen")
+	ErrPrefixSeparator   = errors.New("XUID prefix contains the separator")
+	ErrRegistryConflict  = errors.New("entity or prefix is already registered")
+	ErrUnknownEntity     = errors.New("entity is not registered")
+	ErrEntityMismatch    = errors.New("XUID prefix does not match the registered entity")
+	ErrInvalidGlobalID   = errors.New("global ID is invalid")
+	ErrInvalidSerial     = errors.New("serial number does not hold a UUID")
+	ErrShortHash         = errors.New("hash is shorter than 16 bytes")
+	ErrInvalidGUIDBytes  = errors.New("GUID bytes are not 16 bytes long")
+	ErrNotSortable       = errors.New("XUID is not sortable")
+	ErrInvalidLayout     = errors.New("object key layout is invalid")
+	ErrColumnPrefix      = errors.New("XUID prefix does not match the column prefix")
+	ErrInvalidIndex      = errors.New("index data is invalid")
+	ErrInvalidJSONArray  = errors.New("JSON array of XUIDs is malformed")
+	ErrClockRegression   = errors.New("clock went backwards")
+	ErrPathTemplate      = errors.New("path template does not match the XUIDs")
+	ErrInvalidDest       = errors.New("destination is not a non-nil pointer")
+	ErrPrefixMismatch    = errors.New("XUID prefix does not match the expected prefix")
+	ErrMissingXUID       = errors.New("required XUID is missing")
+
+	ErrInvalidIdempotencyKey = errors.New("idempotency key is invalid")
+	ErrInvalidCompactToken   = errors.New("compact XUID token is invalid")
+	ErrQRPrefix              = errors.New("XUID prefix cannot be encoded in a QR payload")
+	ErrInvalidCanonicalBytes = errors.New("canonical XUID bytes are invalid")
+	ErrIncompatibleSnapshot  = errors.New("generator snapshot is incompatible")
+	ErrNegativeCount         = errors.New("number of XUIDs is negative")
+)
+
+// ParsePart identifies the part of a XUID string in which parsing failed.
+type ParsePart int
+
+const (
+	PartPrefix ParsePart = iota
+	PartPayload
 )
+
+func (p ParsePart) String() string {
+	switch p {
+	case PartPrefix:
+		return "prefix"
+	case PartPayload:
+		return "payload"
+	}
+	return "unknown"
+}
+
+// ParseError describes why and where a XUID string could not be parsed.
+// It wraps ErrParse, so errors.Is(err, ErrParse) keeps working.
+type ParseError struct {
+	// Input is the string that was parsed.
+	Input string
+	// Part is the part of the string in which parsing failed.
+	Part ParsePart
+	// Offset is the byte offset in Input of the first invalid character,
+	// or -1 if the failure is not caused by a single character.
+	Offset int
+	// Char is the invalid character at Offset.
+	Char rune
+	// Reason describes the failure when Offset is -1.
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	if e.Offset >= 0 {
+		return fmt.Sprintf("%s: invalid character %q in %s at position %d", ErrParse, e.Char, e.Part, e.Offset)
+	}
+	return fmt.Sprintf("%s: %s %s", ErrParse, e.Part, e.Reason)
+}
+
+func (e *ParseError) Unwrap() error {
+	return ErrParse
+}
@@ -0,0 +1,49 @@
+package xuid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseError(t *testing.T) {
+	t.Run("reports position of invalid payload character", func(t *testing.T) {
+		_, err := xuid.Parse("user_abcO123")
+
+		var perr *xuid.ParseError
+		require.True(t, errors.As(err, &perr))
+		assert.Equal(t, xuid.PartPayload, perr.Part)
+		assert.Equal(t, 8, perr.Offset)
+		assert.Equal(t, 'O', perr.Char)
+		assert.Equal(t, "user_abcO123", perr.Input)
+		assert.Equal(t, "XUID string cannot be parsed: invalid character 'O' in payload at position 8", err.Error())
+	})
+
+	t.Run("reports empty payload", func(t *testing.T) {
+		_, err := xuid.Parse("user_")
+
+		var perr *xuid.ParseError
+		require.True(t, errors.As(err, &perr))
+		assert.Equal(t, -1, perr.Offset)
+		assert.Equal(t, "XUID string cannot be parsed: payload is empty", err.Error())
+	})
+
+	t.Run("reports payload of wrong length", func(t *testing.T) {
+		_, err := xuid.Parse("user_abc")
+
+		var perr *xuid.ParseError
+		require.True(t, errors.As(err, &perr))
+		assert.Equal(t, xuid.PartPayload, perr.Part)
+		assert.Equal(t, -1, perr.Offset)
+		assert.Contains(t, err.Error(), "does not encode 16 bytes")
+	})
+
+	t.Run("wraps ErrParse", func(t *testing.T) {
+		_, err := xuid.Parse("invalid")
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+}
@@ -0,0 +1,37 @@
+package xuid
+
+import "encoding/binary"
+
+// Fingerprint returns k hash values derived from the UUID bytes.
+// The values are suitable for indexing bloom or cuckoo filters without
+// hashing the string form of the XUID. The prefix does not take part in the
+// fingerprint, so the same UUID always yields the same values.
+func (x XUID) Fingerprint(k int) []uint64 {
+	if k <= 0 {
+		return nil
+	}
+	h1 := mix64(binary.BigEndian.Uint64(x.uuid[:8]))
+	h2 := mix64(binary.BigEndian.Uint64(x.uuid[8:]) ^ h1)
+	// h2 must be odd so the generated sequence never collapses to a single value.
+	h2 |= 1
+
+	res := make([]uint64, k)
+	for i := range res {
+		res[i] = h1 + uint64(i)*h2
+	}
+	return res
+}
+
+// Hash64 returns a 64-bit hash of the UUID bytes. Like Fingerprint, it
+// ignores the prefix.
+func (x XUID) Hash64() uint64 {
+	return mix64(binary.BigEndian.Uint64(x.uuid[8:]) ^ mix64(binary.BigEndian.Uint64(x.uuid[:8])))
+}
+
+// mix64 is the splitmix64 finalizer.
+func mix64(z uint64) uint64 {
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
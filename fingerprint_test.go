@@ -0,0 +1,78 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Run("returns k values", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+
+		assert.Len(t, id.Fingerprint(5), 5)
+	})
+
+	t.Run("returns nil for non-positive k", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+
+		assert.Nil(t, id.Fingerprint(0))
+		assert.Nil(t, id.Fingerprint(-1))
+	})
+
+	t.Run("is deterministic and ignores prefix", func(t *testing.T) {
+		testUUID := uuid.New()
+		id1, _ := xuid.NewWith(testUUID, "user")
+		id2, _ := xuid.NewWith(testUUID, "account")
+
+		assert.Equal(t, id1.Fingerprint(4), id2.Fingerprint(4))
+	})
+
+	t.Run("produces distinct values", func(t *testing.T) {
+		id := xuid.MustNewRandom("user")
+		fp := id.Fingerprint(8)
+
+		seen := map[uint64]bool{}
+		for _, v := range fp {
+			require.False(t, seen[v])
+			seen[v] = true
+		}
+	})
+
+	t.Run("differs between UUIDs", func(t *testing.T) {
+		id1 := xuid.MustNewRandom("user")
+		id2 := xuid.MustNewRandom("user")
+
+		assert.NotEqual(t, id1.Fingerprint(2), id2.Fingerprint(2))
+	})
+}
+
+func TestHash64(t *testing.T) {
+	t.Run("is deterministic and ignores prefix", func(t *testing.T) {
+		a := xuid.MustNewSortable("user")
+		b := xuid.Must(xuid.NewWith(a.GetUUID(), "other"))
+
+		assert.Equal(t, a.Hash64(), b.Hash64())
+	})
+
+	t.Run("differs between UUIDs created in the same millisecond", func(t *testing.T) {
+		seen := make(map[uint64]bool)
+		for i := 0; i < 1000; i++ {
+			h := xuid.MustNewSortable("user").Hash64()
+			assert.False(t, seen[h])
+			seen[h] = true
+		}
+	})
+}
+
+func BenchmarkFingerprint(b *testing.B) {
+	id := xuid.MustNewSortable("bench")
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = id.Fingerprint(4)
+	}
+}
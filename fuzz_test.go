@@ -0,0 +1,26 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidtest"
+)
+
+func FuzzParse(f *testing.F) {
+	xuidtest.SeedParseCorpus(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		xuidtest.CheckParse(t, s)
+	})
+}
+
+func FuzzParseLenient(f *testing.F) {
+	xuidtest.SeedParseCorpus(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		id, err := xuid.ParseLenient(s)
+		if err != nil {
+			return
+		}
+		xuidtest.CheckParse(t, id.String())
+	})
+}
@@ -0,0 +1,69 @@
+package xuid
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// EncodeGlobalID returns the Relay global ID of the entity of type typename
+// identified by x, as formatted by FormatGlobalID. As with DecodeGlobalID,
+// the typename must be registered, and the prefix of x must be the one
+// registered for it.
+func EncodeGlobalID(typename string, x XUID) (string, error) {
+	if err := checkEntity(typename, x); err != nil {
+		return "", err
+	}
+	return FormatGlobalID(typename, x), nil
+}
+
+// DecodeGlobalID splits a Relay global ID into its typename and XUID, as
+// ParseGlobalID does. The typename must be registered, and the prefix of
+// the XUID must be the one registered for it.
+func DecodeGlobalID(id string) (string, XUID, error) {
+	typename, x, err := ParseGlobalID(id)
+	if err != nil {
+		return "", XUID{}, err
+	}
+	if err := checkEntity(typename, x); err != nil {
+		return "", XUID{}, err
+	}
+	return typename, x, nil
+}
+
+// FormatGlobalID returns the base64 encoding of "typename:xuid", the global
+// ID of the entity of type typename identified by x, without consulting the
+// prefix registry.
+func FormatGlobalID(typename string, x XUID) string {
+	return base64.StdEncoding.EncodeToString([]byte(typename + ":" + x.String()))
+}
+
+// ParseGlobalID splits a global ID created by FormatGlobalID into its
+// typename and XUID, without consulting the prefix registry.
+func ParseGlobalID(id string) (string, XUID, error) {
+	raw, err := base64.StdEncoding.DecodeString(id)
+	if err != nil {
+		return "", XUID{}, ErrInvalidGlobalID
+	}
+	typename, idstr, ok := strings.Cut(string(raw), ":")
+	if !ok || typename == "" {
+		return "", XUID{}, ErrInvalidGlobalID
+	}
+	x, err := Parse(idstr)
+	if err != nil {
+		return "", XUID{}, fmt.Errorf("%w: %w", ErrInvalidGlobalID, err)
+	}
+	return typename, x, nil
+}
+
+// checkEntity reports whether typename is registered with the prefix of x.
+func checkEntity(typename string, x XUID) error {
+	prefix, ok := PrefixFor(typename)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownEntity, typename)
+	}
+	if x.prefix != prefix {
+		return fmt.Errorf("%w: %q is not a %s", ErrEntityMismatch, x.String(), typename)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package xuid_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeGlobalID returns the Relay global ID of x, failing the test on
+// error.
+func encodeGlobalID(t *testing.T, typename string, x xuid.XUID) string {
+	t.Helper()
+	id, err := xuid.EncodeGlobalID(typename, x)
+	require.NoError(t, err)
+	return id
+}
+
+func TestGlobalID(t *testing.T) {
+	xuid.MustRegister("GlobalUser", "guser")
+
+	t.Run("encodes typename and XUID as base64", func(t *testing.T) {
+		id := xuid.MustNewSortable("guser")
+
+		raw, err := base64.StdEncoding.DecodeString(encodeGlobalID(t, "GlobalUser", id))
+
+		require.NoError(t, err)
+		assert.Equal(t, "GlobalUser:"+id.String(), string(raw))
+	})
+
+	t.Run("round trips registered entities", func(t *testing.T) {
+		id := xuid.MustNewSortable("guser")
+
+		typename, parsed, err := xuid.DecodeGlobalID(encodeGlobalID(t, "GlobalUser", id))
+
+		require.NoError(t, err)
+		assert.Equal(t, "GlobalUser", typename)
+		assert.True(t, id.Equal(parsed))
+	})
+
+	t.Run("rejects prefix not matching the registered entity", func(t *testing.T) {
+		id := xuid.MustNewSortable("order")
+		raw := base64.StdEncoding.EncodeToString([]byte("GlobalUser:" + id.String()))
+
+		_, err := xuid.EncodeGlobalID("GlobalUser", id)
+		assert.ErrorIs(t, err, xuid.ErrEntityMismatch)
+
+		_, _, err = xuid.DecodeGlobalID(raw)
+		assert.ErrorIs(t, err, xuid.ErrEntityMismatch)
+	})
+
+	t.Run("rejects unregistered typenames", func(t *testing.T) {
+		id := xuid.MustNewSortable("guser")
+		raw := base64.StdEncoding.EncodeToString([]byte("Unregistered:" + id.String()))
+
+		_, err := xuid.EncodeGlobalID("Unregistered", id)
+		assert.ErrorIs(t, err, xuid.ErrUnknownEntity)
+
+		_, _, err = xuid.DecodeGlobalID(raw)
+		assert.ErrorIs(t, err, xuid.ErrUnknownEntity)
+	})
+
+	t.Run("rejects malformed global IDs", func(t *testing.T) {
+		inputs := []string{
+			"not base64!",
+			base64.StdEncoding.EncodeToString([]byte("GlobalUser")),
+			base64.StdEncoding.EncodeToString([]byte("GlobalUser:invalid_id")),
+		}
+		for _, input := range inputs {
+			_, _, err := xuid.DecodeGlobalID(input)
+
+			assert.ErrorIs(t, err, xuid.ErrInvalidGlobalID, input)
+		}
+	})
+}
+
+func TestParseGlobalID(t *testing.T) {
+	t.Run("round trips without the registry", func(t *testing.T) {
+		id := xuid.MustNewSortable("unregistered")
+
+		typename, parsed, err := xuid.ParseGlobalID(xuid.FormatGlobalID("Unregistered", id))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Unregistered", typename)
+		assert.True(t, id.Equal(parsed))
+	})
+
+	t.Run("formats like EncodeGlobalID", func(t *testing.T) {
+		xuid.MustRegister("FormatUser", "fuser")
+		id := xuid.MustNewSortable("fuser")
+
+		assert.Equal(t, encodeGlobalID(t, "FormatUser", id), xuid.FormatGlobalID("FormatUser", id))
+	})
+
+	t.Run("rejects malformed global IDs", func(t *testing.T) {
+		_, _, err := xuid.ParseGlobalID("not base64!")
+
+		assert.ErrorIs(t, err, xuid.ErrInvalidGlobalID)
+	})
+}
@@ -0,0 +1,55 @@
+package xuid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// FormatGUID returns the UUID in the braced, upper-case registry format used
+// on Windows, such as "{01890A5D-AC96-774B-BCCE-B302099A8057}".
+func (x XUID) FormatGUID() string {
+	return "{" + strings.ToUpper(x.uuid.String()) + "}"
+}
+
+// ParseGUID parses a GUID string, braced or not, in any letter case.
+// The prefix is not part of the GUID and must be passed in.
+func ParseGUID(s, prefix string) (XUID, error) {
+	if len(s) != 38 && len(s) != 36 {
+		return XUID{}, fmt.Errorf("%w: %q is not a GUID", ErrInvalidUUIDString, s)
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return XUID{}, fmt.Errorf("%w: %v", ErrInvalidUUIDString, err)
+	}
+	return NewWith(id, prefix)
+}
+
+// GUIDBytes returns the UUID in the mixed-endian layout of the Windows GUID
+// struct, used by .NET Guid.ToByteArray and SQL Server uniqueidentifier:
+// the first three groups are little-endian, the last two are unchanged.
+func (x XUID) GUIDBytes() []byte {
+	b := swapGUID(x.uuid)
+	return b[:]
+}
+
+// FromGUIDBytes is the inverse of GUIDBytes.
+// The prefix is not part of the GUID and must be passed in.
+func FromGUIDBytes(b []byte, prefix string) (XUID, error) {
+	if len(b) != 16 {
+		return XUID{}, ErrInvalidGUIDBytes
+	}
+	var id uuid.UUID
+	copy(id[:], b)
+	return NewWith(swapGUID(id), prefix)
+}
+
+// swapGUID converts between RFC 4122 and GUID byte order.
+// The conversion is its own inverse.
+func swapGUID(id uuid.UUID) uuid.UUID {
+	id[0], id[1], id[2], id[3] = id[3], id[2], id[1], id[0]
+	id[4], id[5] = id[5], id[4]
+	id[6], id[7] = id[7], id[6]
+	return id
+}
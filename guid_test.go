@@ -0,0 +1,72 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGUID(t *testing.T) {
+	id := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	x, err := xuid.NewWith(id, "user")
+	require.NoError(t, err)
+
+	t.Run("formats braced upper-case GUID", func(t *testing.T) {
+		assert.Equal(t, "{01890A5D-AC96-774B-BCCE-B302099A8057}", x.FormatGUID())
+	})
+
+	t.Run("parses braced and unbraced GUIDs", func(t *testing.T) {
+		for _, s := range []string{
+			"{01890A5D-AC96-774B-BCCE-B302099A8057}",
+			"{01890a5d-ac96-774b-bcce-b302099a8057}",
+			"01890A5D-AC96-774B-BCCE-B302099A8057",
+		} {
+			parsed, err := xuid.ParseGUID(s, "user")
+
+			require.NoError(t, err, s)
+			assert.True(t, x.Equal(parsed), s)
+		}
+	})
+
+	t.Run("rejects invalid GUIDs", func(t *testing.T) {
+		for _, s := range []string{
+			"",
+			"{01890A5D-AC96-774B-BCCE-B302099A8057",
+			"01890A5DAC96774BBCCEB302099A8057",
+			"urn:uuid:01890a5d-ac96-774b-bcce-b302099a8057",
+			"{01890A5D-AC96-774B-BCCE-B302099A805Z}",
+		} {
+			_, err := xuid.ParseGUID(s, "user")
+
+			assert.ErrorIs(t, err, xuid.ErrInvalidUUIDString, s)
+		}
+	})
+
+	t.Run("GUIDBytes uses mixed-endian layout", func(t *testing.T) {
+		// Matches new Guid("01890a5d-ac96-774b-bcce-b302099a8057").ToByteArray() in .NET.
+		want := []byte{
+			0x5d, 0x0a, 0x89, 0x01,
+			0x96, 0xac,
+			0x4b, 0x77,
+			0xbc, 0xce, 0xb3, 0x02, 0x09, 0x9a, 0x80, 0x57,
+		}
+
+		assert.Equal(t, want, x.GUIDBytes())
+	})
+
+	t.Run("FromGUIDBytes round-trips", func(t *testing.T) {
+		parsed, err := xuid.FromGUIDBytes(x.GUIDBytes(), "user")
+
+		require.NoError(t, err)
+		assert.True(t, x.Equal(parsed))
+	})
+
+	t.Run("FromGUIDBytes rejects wrong length", func(t *testing.T) {
+		_, err := xuid.FromGUIDBytes(make([]byte, 15), "user")
+
+		assert.ErrorIs(t, err, xuid.ErrInvalidGUIDBytes)
+	})
+}
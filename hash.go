@@ -0,0 +1,39 @@
+package xuid
+
+import (
+	"crypto/sha256"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// NewFromHash creates a XUID holding a UUIDv8 made of the first 16 bytes of
+// sum, typically the output of a cryptographic hash. The version and variant
+// bits overwrite 6 bits of sum, leaving 122 bits of the hash in the UUID.
+func NewFromHash(sum []byte, prefix string) (XUID, error) {
+	if len(sum) < 16 {
+		return XUID{}, ErrShortHash
+	}
+	return NewWith(hashUUID(sum), prefix)
+}
+
+// hashUUID returns the UUIDv8 made of the first 16 bytes of sum.
+func hashUUID(sum []byte) uuid.UUID {
+	var id uuid.UUID
+	copy(id[:], sum)
+	id[6] = (id[6] & 0x0f) | 0x80 // version 8
+	id[8] = (id[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return id
+}
+
+// FromContent creates a content-addressed XUID: a UUIDv8 made with
+// NewFromHash from the SHA-256 of everything read from r. Identical
+// contents always yield the same UUID, whatever the prefix, which suits
+// blob stores and deduplication.
+func FromContent(prefix string, r io.Reader) (XUID, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return XUID{}, err
+	}
+	return NewFromHash(h.Sum(nil), prefix)
+}
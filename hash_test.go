@@ -0,0 +1,70 @@
+package xuid_test
+
+import (
+	"crypto/sha256"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromHash(t *testing.T) {
+	t.Run("creates version 8 XUID from hash", func(t *testing.T) {
+		sum := sha256.Sum256([]byte("content"))
+
+		id, err := xuid.NewFromHash(sum[:], "blob")
+
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Version(8), id.GetUUID().Version())
+		assert.Equal(t, uuid.RFC4122, id.GetUUID().Variant())
+		assert.Equal(t, "blob", id.GetPrefix())
+		u := id.GetUUID()
+		assert.Equal(t, sum[:6], u[:6])
+	})
+
+	t.Run("is deterministic", func(t *testing.T) {
+		sum := sha256.Sum256([]byte("content"))
+
+		id1, _ := xuid.NewFromHash(sum[:], "blob")
+		id2, _ := xuid.NewFromHash(sum[:], "blob")
+
+		assert.True(t, id1.Equal(id2))
+	})
+
+	t.Run("rejects short hashes", func(t *testing.T) {
+		_, err := xuid.NewFromHash(make([]byte, 15), "blob")
+
+		assert.ErrorIs(t, err, xuid.ErrShortHash)
+	})
+}
+
+func TestFromContent(t *testing.T) {
+	t.Run("hashes the content with SHA-256", func(t *testing.T) {
+		id, err := xuid.FromContent("blob", strings.NewReader("hello world"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "b94d27b9-934d-8e08-a52e-52d7da7dabfa", id.GetUUID().String())
+		assert.Equal(t, "blob", id.GetPrefix())
+	})
+
+	t.Run("matches NewFromHash", func(t *testing.T) {
+		sum := sha256.Sum256([]byte("content"))
+		want, _ := xuid.NewFromHash(sum[:], "blob")
+
+		id, err := xuid.FromContent("blob", strings.NewReader("content"))
+
+		require.NoError(t, err)
+		assert.Equal(t, want, id)
+	})
+
+	t.Run("reports read errors", func(t *testing.T) {
+		_, err := xuid.FromContent("blob", iotest.ErrReader(io.ErrUnexpectedEOF))
+
+		assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+}
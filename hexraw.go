@@ -0,0 +1,29 @@
+package xuid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// HexRaw returns the UUID as 32 upper-case hex digits, the form produced by
+// Oracle RAWTOHEX and accepted by HEXTORAW, for embedding in PL/SQL.
+func (x XUID) HexRaw() string {
+	return strings.ToUpper(hex.EncodeToString(x.uuid[:]))
+}
+
+// ParseHexRaw parses 32 hex digits, in any letter case, as produced by
+// HexRaw or RAWTOHEX.
+// The prefix is not part of the hex form and must be passed in.
+func ParseHexRaw(s, prefix string) (XUID, error) {
+	var id uuid.UUID
+	if len(s) != hex.EncodedLen(len(id)) {
+		return XUID{}, fmt.Errorf("%w: %q is not 32 hex digits", ErrInvalidUUIDString, s)
+	}
+	if _, err := hex.Decode(id[:], []byte(s)); err != nil {
+		return XUID{}, fmt.Errorf("%w: %v", ErrInvalidUUIDString, err)
+	}
+	return NewWith(id, prefix)
+}
@@ -0,0 +1,37 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHexRaw(t *testing.T) {
+	id := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	x, err := xuid.NewWith(id, "user")
+	require.NoError(t, err)
+
+	t.Run("formats upper-case hex", func(t *testing.T) {
+		assert.Equal(t, "01890A5DAC96774BBCCEB302099A8057", x.HexRaw())
+	})
+
+	t.Run("parses hex in any case", func(t *testing.T) {
+		for _, s := range []string{"01890A5DAC96774BBCCEB302099A8057", "01890a5dac96774bbcceb302099a8057"} {
+			parsed, err := xuid.ParseHexRaw(s, "user")
+
+			require.NoError(t, err)
+			assert.True(t, x.Equal(parsed))
+		}
+	})
+
+	t.Run("rejects invalid hex", func(t *testing.T) {
+		for _, s := range []string{"", "01890A5D", "01890a5d-ac96-774b-bcce-b302099a8057", "01890A5DAC96774BBCCEB302099A805Z"} {
+			_, err := xuid.ParseHexRaw(s, "user")
+
+			assert.ErrorIs(t, err, xuid.ErrInvalidUUIDString, s)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package xuid
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader is the HTTP header carrying idempotency keys, as
+// proposed by the IETF httpapi working group.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// NamespaceIdempotency is the namespace of derived idempotency keys.
+var NamespaceIdempotency = NamespaceFor("idempotency")
+
+// NewIdempotencyKey creates a sortable idempotency key for scope, which is
+// used as prefix. Sortable keys let stores expire old keys by range.
+func NewIdempotencyKey(scope string) (XUID, error) {
+	return NewSortable(scope)
+}
+
+// DeriveIdempotencyKey derives the idempotency key of a request from its
+// content, so that retries of identical requests share a key.
+func DeriveIdempotencyKey(scope string, content []byte) (XUID, error) {
+	name := make([]byte, 0, len(scope)+1+len(content))
+	name = append(append(append(name, scope...), 0), content...)
+	return NewWith(uuid.NewSHA1(NamespaceIdempotency, name), scope)
+}
+
+// ParseIdempotencyKey parses an idempotency key and checks that it belongs
+// to scope and was created by NewIdempotencyKey or DeriveIdempotencyKey.
+func ParseIdempotencyKey(s string, scope string) (XUID, error) {
+	xid, err := Parse(s)
+	if err != nil {
+		return XUID{}, fmt.Errorf("%w: %w", ErrInvalidIdempotencyKey, err)
+	}
+	if xid.prefix != scope {
+		return XUID{}, fmt.Errorf("%w: scope must be %q", ErrInvalidIdempotencyKey, scope)
+	}
+	if v := xid.uuid.Version(); v != 7 && v != 5 {
+		return XUID{}, fmt.Errorf("%w: unexpected UUID version %d", ErrInvalidIdempotencyKey, v)
+	}
+	return xid, nil
+}
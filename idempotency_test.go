@@ -0,0 +1,74 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	t.Run("creates sortable key with scope as prefix", func(t *testing.T) {
+		key, err := xuid.NewIdempotencyKey("pay")
+
+		require.NoError(t, err)
+		assert.True(t, key.IsSortable())
+		assert.Equal(t, "pay", key.GetPrefix())
+	})
+
+	t.Run("derives equal keys from equal content", func(t *testing.T) {
+		key1, err1 := xuid.DeriveIdempotencyKey("pay", []byte(`{"amount":10}`))
+		key2, err2 := xuid.DeriveIdempotencyKey("pay", []byte(`{"amount":10}`))
+
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.True(t, key1.Equal(key2))
+	})
+
+	t.Run("derives different keys per scope and content", func(t *testing.T) {
+		key, _ := xuid.DeriveIdempotencyKey("pay", []byte(`{"amount":10}`))
+		otherContent, _ := xuid.DeriveIdempotencyKey("pay", []byte(`{"amount":11}`))
+		otherScope, _ := xuid.DeriveIdempotencyKey("refund", []byte(`{"amount":10}`))
+
+		assert.NotEqual(t, key.GetUUID(), otherContent.GetUUID())
+		assert.NotEqual(t, key.GetUUID(), otherScope.GetUUID())
+	})
+
+	t.Run("parses generated and derived keys", func(t *testing.T) {
+		generated, _ := xuid.NewIdempotencyKey("pay")
+		derived, _ := xuid.DeriveIdempotencyKey("pay", []byte("body"))
+
+		for _, key := range []xuid.XUID{generated, derived} {
+			parsed, err := xuid.ParseIdempotencyKey(key.String(), "pay")
+
+			require.NoError(t, err)
+			assert.True(t, key.Equal(parsed))
+		}
+	})
+
+	t.Run("rejects keys of other scopes", func(t *testing.T) {
+		key, _ := xuid.NewIdempotencyKey("refund")
+
+		_, err := xuid.ParseIdempotencyKey(key.String(), "pay")
+
+		assert.ErrorIs(t, err, xuid.ErrInvalidIdempotencyKey)
+	})
+
+	t.Run("rejects random XUIDs", func(t *testing.T) {
+		_, err := xuid.ParseIdempotencyKey(xuid.MustNewRandom("pay").String(), "pay")
+
+		assert.ErrorIs(t, err, xuid.ErrInvalidIdempotencyKey)
+	})
+
+	t.Run("rejects malformed keys", func(t *testing.T) {
+		_, err := xuid.ParseIdempotencyKey("pay_invalid", "pay")
+
+		assert.ErrorIs(t, err, xuid.ErrInvalidIdempotencyKey)
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+
+	t.Run("exposes header name", func(t *testing.T) {
+		assert.Equal(t, "Idempotency-Key", xuid.IdempotencyKeyHeader)
+	})
+}
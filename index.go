@@ -0,0 +1,88 @@
+package xuid
+
+// Index assigns dense integer handles to XUIDs, in the order they are
+// added, so that graph and set computations over large ID sets can work on
+// uint32 values, such as members of roaring bitmaps, instead of 16 byte
+// UUIDs. Handles are stable: a XUID keeps its handle for the lifetime of
+// the index, including across MarshalBinary and UnmarshalBinary.
+//
+// An Index is not safe for concurrent use. The zero value is an empty index.
+type Index struct {
+	ids     []XUID
+	handles map[XUID]uint32
+}
+
+// NewIndex returns an empty index with room for n XUIDs.
+func NewIndex(n int) *Index {
+	return &Index{ids: make([]XUID, 0, n), handles: make(map[XUID]uint32, n)}
+}
+
+// Add returns the handle of x, assigning the next handle if x is not in
+// the index yet.
+func (idx *Index) Add(x XUID) uint32 {
+	if h, ok := idx.handles[x]; ok {
+		return h
+	}
+	if idx.handles == nil {
+		idx.handles = map[XUID]uint32{}
+	}
+	h := uint32(len(idx.ids))
+	idx.ids = append(idx.ids, x)
+	idx.handles[x] = h
+	return h
+}
+
+// Handle returns the handle of x, if x is in the index.
+func (idx *Index) Handle(x XUID) (uint32, bool) {
+	h, ok := idx.handles[x]
+	return h, ok
+}
+
+// XUID returns the XUID of handle h. It panics if h was not assigned.
+func (idx *Index) XUID(h uint32) XUID {
+	return idx.ids[h]
+}
+
+// Len returns the number of XUIDs in the index, which is also the next
+// handle to be assigned.
+func (idx *Index) Len() int {
+	return len(idx.ids)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// XUIDs are written in handle order, each in the form of CanonicalBytes,
+// so it fails like CanonicalBytes on prefixes longer than MaxPrefixLen.
+func (idx *Index) MarshalBinary() ([]byte, error) {
+	n := 0
+	for _, x := range idx.ids {
+		n += 1 + len(x.prefix) + 16
+	}
+	b := make([]byte, 0, n)
+	for _, x := range idx.ids {
+		var err error
+		if b, err = x.AppendCanonicalBytes(b); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+// It replaces the content of the index with the XUIDs in data, restoring
+// their handles.
+func (idx *Index) UnmarshalBinary(data []byte) error {
+	res := NewIndex(0)
+	for len(data) > 0 {
+		x, rest, ok := readCanonical(data)
+		if !ok {
+			return ErrInvalidIndex
+		}
+		if _, ok := res.handles[x]; ok {
+			return ErrInvalidIndex
+		}
+		res.Add(x)
+		data = rest
+	}
+	*idx = *res
+	return nil
+}
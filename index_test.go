@@ -0,0 +1,86 @@
+package xuid_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex(t *testing.T) {
+	t.Run("assigns dense stable handles", func(t *testing.T) {
+		a, b := xuid.MustNewSortable("user"), xuid.MustNewSortable("order")
+		idx := xuid.NewIndex(2)
+
+		assert.Equal(t, uint32(0), idx.Add(a))
+		assert.Equal(t, uint32(1), idx.Add(b))
+		assert.Equal(t, uint32(0), idx.Add(a))
+		assert.Equal(t, 2, idx.Len())
+		assert.Equal(t, b, idx.XUID(1))
+		h, ok := idx.Handle(b)
+		assert.True(t, ok)
+		assert.Equal(t, uint32(1), h)
+	})
+
+	t.Run("reports missing XUIDs", func(t *testing.T) {
+		var idx xuid.Index
+
+		_, ok := idx.Handle(xuid.MustNewSortable("user"))
+
+		assert.False(t, ok)
+	})
+
+	t.Run("distinguishes prefixes", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+		y, _ := xuid.NewWith(x.GetUUID(), "member")
+		var idx xuid.Index
+
+		assert.NotEqual(t, idx.Add(x), idx.Add(y))
+	})
+
+	t.Run("round-trips handles through binary", func(t *testing.T) {
+		idx := xuid.NewIndex(0)
+		for i := 0; i < 10; i++ {
+			idx.Add(xuid.MustNewRandom("user"))
+		}
+		idx.Add(xuid.MustNewRandom(""))
+		data, err := idx.MarshalBinary()
+		require.NoError(t, err)
+
+		var restored xuid.Index
+		require.NoError(t, restored.UnmarshalBinary(data))
+
+		assert.Equal(t, idx.Len(), restored.Len())
+		for h := uint32(0); h < uint32(idx.Len()); h++ {
+			x := idx.XUID(h)
+			assert.Equal(t, x, restored.XUID(h))
+			got, ok := restored.Handle(x)
+			assert.True(t, ok)
+			assert.Equal(t, h, got)
+		}
+	})
+
+	t.Run("fails to marshal prefixes longer than MaxPrefixLen", func(t *testing.T) {
+		idx := xuid.NewIndex(0)
+		x := xuid.MustNewRandom("")
+		x.SetPrefix(strings.Repeat("a", xuid.MaxPrefixLen+1))
+		idx.Add(x)
+
+		_, err := idx.MarshalBinary()
+
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+	})
+
+	t.Run("rejects truncated data", func(t *testing.T) {
+		idx := xuid.NewIndex(0)
+		idx.Add(xuid.MustNewRandom("user"))
+		data, _ := idx.MarshalBinary()
+
+		var restored xuid.Index
+		err := restored.UnmarshalBinary(data[:len(data)-1])
+
+		assert.ErrorIs(t, err, xuid.ErrInvalidIndex)
+	})
+}
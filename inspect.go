@@ -0,0 +1,55 @@
+package xuid
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Info describes a XUID string.
+type Info struct {
+	// Prefix is the prefix of the string, if any.
+	Prefix string
+	// Payload is the base58 encoded UUID.
+	Payload string
+	// UUID is the decoded UUID.
+	UUID uuid.UUID
+	// Version and Variant are the UUID version and variant.
+	Version uuid.Version
+	Variant uuid.Variant
+	// Time is the creation time embedded in sortable XUIDs. It is the zero
+	// time for other versions.
+	Time time.Time
+	// EncodedLen is the length of the payload.
+	EncodedLen int
+}
+
+// InspectString describes the XUID string s without creating a XUID.
+// It fails in exactly the cases Parse fails, with the same errors.
+func InspectString(s string) (Info, error) {
+	prefix, payload, id, err := parseParts(s)
+	if err != nil {
+		return Info{}, err
+	}
+	info := Info{
+		Prefix:     prefix,
+		Payload:    payload,
+		UUID:       id,
+		Version:    id.Version(),
+		Variant:    id.Variant(),
+		EncodedLen: len(payload),
+	}
+	if info.Version == 7 {
+		info.Time = v7Time(id)
+	}
+	return info, nil
+}
+
+// v7Time returns the millisecond Unix timestamp stored in the first 48 bits
+// of a UUIDv7.
+func v7Time(id uuid.UUID) time.Time {
+	var b [8]byte
+	copy(b[2:], id[:6])
+	return time.UnixMilli(int64(binary.BigEndian.Uint64(b[:])))
+}
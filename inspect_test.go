@@ -0,0 +1,70 @@
+package xuid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectString(t *testing.T) {
+	t.Run("describes sortable XUID", func(t *testing.T) {
+		before := time.Now().Truncate(time.Millisecond)
+		id := xuid.MustNewSortable("user")
+		s := id.String()
+
+		info, err := xuid.InspectString(s)
+
+		require.NoError(t, err)
+		assert.Equal(t, "user", info.Prefix)
+		assert.Equal(t, s[len("user_"):], info.Payload)
+		assert.Equal(t, id.GetUUID(), info.UUID)
+		assert.Equal(t, uuid.Version(7), info.Version)
+		assert.Equal(t, uuid.RFC4122, info.Variant)
+		assert.Equal(t, len(info.Payload), info.EncodedLen)
+		assert.False(t, info.Time.Before(before))
+		assert.False(t, info.Time.After(time.Now()))
+	})
+
+	t.Run("describes random XUID without prefix", func(t *testing.T) {
+		id := xuid.MustNewRandom("")
+
+		info, err := xuid.InspectString(id.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, "", info.Prefix)
+		assert.Equal(t, id.String(), info.Payload)
+		assert.Equal(t, uuid.Version(4), info.Version)
+		assert.True(t, info.Time.IsZero())
+	})
+
+	t.Run("keeps separators in prefix", func(t *testing.T) {
+		id := xuid.MustNewSortable("user_profile")
+
+		info, err := xuid.InspectString(id.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, "user_profile", info.Prefix)
+	})
+
+	t.Run("describes legacy payload_prefix strings", func(t *testing.T) {
+		withConfig(t, xuid.Config{LegacyLayout: xuid.LegacyPrefixSuffix})
+		id := xuid.MustNewSortable("")
+
+		info, err := xuid.InspectString(id.String() + "_user")
+
+		require.NoError(t, err)
+		assert.Equal(t, "user", info.Prefix)
+		assert.Equal(t, id.String(), info.Payload)
+		assert.Equal(t, id.GetUUID(), info.UUID)
+	})
+
+	t.Run("returns parse errors", func(t *testing.T) {
+		_, err := xuid.InspectString("user_abcO123")
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+}
@@ -0,0 +1,54 @@
+package xuid
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// interned holds the canonical copy of each interned prefix. It is replaced
+// on every change, so parsing reads it without locking or allocating.
+var (
+	interned   atomic.Pointer[map[string]string]
+	internedMu sync.Mutex
+)
+
+// InternPrefixes makes parsed XUIDs with one of prefixes share a single
+// copy of the prefix string, instead of each holding a slice of its input.
+// This saves memory, and lets inputs be garbage collected, when millions of
+// XUIDs with a few prefixes are kept in memory. Prefixes passed to Register
+// are interned automatically.
+//
+// Interned prefixes are never released, so only intern a known, bounded set
+// of prefixes.
+func InternPrefixes(prefixes ...string) {
+	internedMu.Lock()
+	defer internedMu.Unlock()
+
+	m := make(map[string]string)
+	if old := interned.Load(); old != nil {
+		for k, v := range *old {
+			m[k] = v
+		}
+	}
+	for _, p := range prefixes {
+		if _, ok := m[p]; !ok {
+			p = strings.Clone(p)
+			m[p] = p
+		}
+	}
+	interned.Store(&m)
+}
+
+// internPrefix returns the interned copy of prefix, or prefix itself if it
+// is not interned.
+func internPrefix(prefix string) string {
+	m := interned.Load()
+	if m == nil {
+		return prefix
+	}
+	if p, ok := (*m)[prefix]; ok {
+		return p
+	}
+	return prefix
+}
@@ -0,0 +1,50 @@
+package xuid_test
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInternPrefixes(t *testing.T) {
+	t.Run("parsed XUIDs share interned prefixes", func(t *testing.T) {
+		xuid.InternPrefixes("interned")
+		a, err := xuid.Parse(xuid.MustNewSortable("interned").String())
+		require.NoError(t, err)
+		b, err := xuid.Parse(xuid.MustNewSortable("interned").String())
+		require.NoError(t, err)
+
+		assert.Equal(t, "interned", a.GetPrefix())
+		assert.Equal(t, unsafe.StringData(a.GetPrefix()), unsafe.StringData(b.GetPrefix()))
+	})
+
+	t.Run("other prefixes are kept as parsed", func(t *testing.T) {
+		s := xuid.MustNewSortable("notinterned").String()
+
+		x, err := xuid.Parse(s)
+
+		require.NoError(t, err)
+		assert.Equal(t, "notinterned", x.GetPrefix())
+		assert.Equal(t, unsafe.StringData(s), unsafe.StringData(x.GetPrefix()))
+	})
+
+	t.Run("registered prefixes are interned", func(t *testing.T) {
+		require.NoError(t, xuid.Register("InternedEntity", "internedentity"))
+		a, _ := xuid.Parse(xuid.MustNewSortable("internedentity").String())
+		b, _ := xuid.Parse(xuid.MustNewSortable("internedentity").String())
+
+		assert.Equal(t, unsafe.StringData(a.GetPrefix()), unsafe.StringData(b.GetPrefix()))
+	})
+}
+
+func BenchmarkParseInterned(b *testing.B) {
+	xuid.InternPrefixes("user")
+	s := xuid.MustNewSortable("user").String()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = xuid.Parse(s)
+	}
+}
@@ -2,6 +2,7 @@ package xuid
 
 import (
 	"encoding/json"
+	"fmt"
 )
 
 func (x XUID) MarshalJSON() ([]byte, error) {
@@ -14,11 +15,97 @@ func (x *XUID) UnmarshalJSON(data []byte) error {
 	if err != nil {
 		return err
 	}
-	xid, err := Parse(res)
-	if err != nil {
-		return err
+	return ParseInto(x, res)
+}
+
+// UnmarshalJSONArray decodes a JSON array of XUID strings, or null, which
+// yields a nil slice. It scans data directly instead of decoding it into a
+// []string first, which matters for requests carrying thousands of IDs.
+// Errors in an element report its index.
+func UnmarshalJSONArray(data []byte) ([]XUID, error) {
+	i := skipJSONSpace(data, 0)
+	if string(data[i:min(i+4, len(data))]) == "null" {
+		if skipJSONSpace(data, i+4) != len(data) {
+			return nil, jsonArrayError(i + 4)
+		}
+		return nil, nil
+	}
+	if i >= len(data) || data[i] != '[' {
+		return nil, jsonArrayError(i)
+	}
+	res := make([]XUID, 0, countJSONElements(data))
+	i = skipJSONSpace(data, i+1)
+	if i < len(data) && data[i] == ']' {
+		i++
+	} else {
+		for {
+			if i >= len(data) || data[i] != '"' {
+				return nil, jsonArrayError(i)
+			}
+			end, escaped := i+1, false
+			for end < len(data) && data[end] != '"' {
+				if data[end] < 0x20 {
+					return nil, jsonArrayError(end)
+				}
+				if data[end] == '\\' {
+					escaped = true
+					end++
+				}
+				end++
+			}
+			if end >= len(data) {
+				return nil, jsonArrayError(end)
+			}
+			var s string
+			if escaped {
+				if err := json.Unmarshal(data[i:end+1], &s); err != nil {
+					return nil, fmt.Errorf("index %d: %w", len(res), err)
+				}
+			} else {
+				s = string(data[i+1 : end])
+			}
+			var x XUID
+			if err := ParseInto(&x, s); err != nil {
+				return nil, fmt.Errorf("index %d: %w", len(res), err)
+			}
+			res = append(res, x)
+
+			i = skipJSONSpace(data, end+1)
+			if i < len(data) && data[i] == ']' {
+				i++
+				break
+			}
+			if i >= len(data) || data[i] != ',' {
+				return nil, jsonArrayError(i)
+			}
+			i = skipJSONSpace(data, i+1)
+		}
+	}
+	if i = skipJSONSpace(data, i); i != len(data) {
+		return nil, jsonArrayError(i)
 	}
-	x.uuid = xid.uuid
-	x.prefix = xid.prefix
-	return nil
+	return res, nil
+}
+
+func skipJSONSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// countJSONElements returns an upper bound of the number of elements of the
+// JSON array in data, for preallocation.
+func countJSONElements(data []byte) int {
+	n := 1
+	for _, c := range data {
+		if c == ',' {
+			n++
+		}
+	}
+	return n
+}
+
+func jsonArrayError(offset int) error {
+	return fmt.Errorf("%w at offset %d", ErrInvalidJSONArray, offset)
 }
@@ -0,0 +1,75 @@
+package xuid_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalJSONArray(t *testing.T) {
+	t.Run("decodes arrays", func(t *testing.T) {
+		ids := []xuid.XUID{xuid.MustNewSortable("user"), xuid.MustNewRandom(""), xuid.MustNewSortable("order")}
+		data, err := json.Marshal(ids)
+		require.NoError(t, err)
+
+		got, err := xuid.UnmarshalJSONArray(data)
+
+		require.NoError(t, err)
+		assert.Equal(t, ids, got)
+	})
+
+	t.Run("accepts whitespace and escapes", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+		data := " [\n\t\"\\u0075ser_" + strings.TrimPrefix(x.String(), "user_") + "\" ] "
+
+		got, err := xuid.UnmarshalJSONArray([]byte(data))
+
+		require.NoError(t, err)
+		assert.Equal(t, []xuid.XUID{x}, got)
+	})
+
+	t.Run("decodes empty arrays and null", func(t *testing.T) {
+		got, err := xuid.UnmarshalJSONArray([]byte("[ ]"))
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		assert.NotNil(t, got)
+
+		got, err = xuid.UnmarshalJSONArray([]byte("null"))
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("reports index of invalid XUIDs", func(t *testing.T) {
+		data := `["` + xuid.MustNewSortable("user").String() + `","user_0OIl"]`
+
+		_, err := xuid.UnmarshalJSONArray([]byte(data))
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+		assert.Contains(t, err.Error(), "index 1")
+	})
+
+	t.Run("rejects malformed JSON", func(t *testing.T) {
+		x := xuid.MustNewSortable("user").String()
+		for _, data := range []string{``, `{}`, `[`, `["` + x, `["` + x + `",]`, `["` + x + `" "` + x + `"]`, `[1]`, `[] x`, `nullx`} {
+			_, err := xuid.UnmarshalJSONArray([]byte(data))
+
+			assert.ErrorIs(t, err, xuid.ErrInvalidJSONArray, data)
+		}
+	})
+}
+
+func BenchmarkUnmarshalJSONArray(b *testing.B) {
+	ids := make([]xuid.XUID, 1000)
+	for i := range ids {
+		ids[i] = xuid.MustNewSortable("user")
+	}
+	data, _ := json.Marshal(ids)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = xuid.UnmarshalJSONArray(data)
+	}
+}
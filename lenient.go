@@ -0,0 +1,59 @@
+package xuid
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ParseLenient parses a XUID string typed or pasted by a human.
+// Before strict parsing it removes zero-width and other invisible
+// characters, trims surrounding whitespace and strips surrounding quotes,
+// including typographic ones.
+// Use Parse for machine input, where such characters indicate a bug.
+func ParseLenient(idstr string) (XUID, error) {
+	return Parse(cleanup(idstr))
+}
+
+func cleanup(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if isInvisible(r) {
+			return -1
+		}
+		return r
+	}, s)
+	for {
+		t := strings.TrimFunc(s, unicode.IsSpace)
+		t = trimQuotes(t)
+		if t == s {
+			return s
+		}
+		s = t
+	}
+}
+
+func isInvisible(r rune) bool {
+	switch r {
+	case '\u200b', '\u200c', '\u200d', '\u2060', '\ufeff', '\u00ad': // zero-width characters, BOM and soft hyphen
+		return true
+	}
+	return false
+}
+
+var quotePairs = [][2]string{
+	{`"`, `"`},
+	{`'`, `'`},
+	{"`", "`"},
+	{"\u201c", "\u201d"}, // “ ”
+	{"\u2018", "\u2019"}, // ‘ ’
+	{"\u201e", "\u201c"}, // „ “
+	{"\u00ab", "\u00bb"}, // « »
+}
+
+func trimQuotes(s string) string {
+	for _, q := range quotePairs {
+		if len(s) >= len(q[0])+len(q[1]) && strings.HasPrefix(s, q[0]) && strings.HasSuffix(s, q[1]) {
+			return s[len(q[0]) : len(s)-len(q[1])]
+		}
+	}
+	return s
+}
@@ -0,0 +1,45 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLenient(t *testing.T) {
+	id := xuid.MustNewSortable("user")
+	s := id.String()
+
+	cases := map[string]string{
+		"surrounding whitespace": "  \t" + s + "\n",
+		"non-breaking spaces":    "\u00a0" + s + "\u00a0",
+		"zero-width spaces":      "\u200b" + s[:3] + "\u200b" + s[3:] + "\ufeff",
+		"ascii quotes":           `"` + s + `"`,
+		"smart double quotes":    "\u201c" + s + "\u201d",
+		"smart single quotes":    "\u2018" + s + "\u2019",
+		"quotes and whitespace":  " \u201c " + s + " \u201d ",
+		"backticks":              "`" + s + "`",
+	}
+	for name, input := range cases {
+		t.Run("cleans up "+name, func(t *testing.T) {
+			parsed, err := xuid.ParseLenient(input)
+
+			require.NoError(t, err)
+			assert.True(t, id.Equal(parsed))
+		})
+	}
+
+	t.Run("still rejects invalid input", func(t *testing.T) {
+		_, err := xuid.ParseLenient(" invalid_string ")
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+
+	t.Run("strict Parse rejects the same junk", func(t *testing.T) {
+		_, err := xuid.Parse(s + "\u200b")
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+}
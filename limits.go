@@ -0,0 +1,43 @@
+package xuid
+
+import "strconv"
+
+const (
+	// Separator separates the prefix from the payload in the string form.
+	Separator = "_"
+
+	// EncodedLenMin and EncodedLenMax bound the length of the base58 payload,
+	// which varies with the UUID: only UUIDs from 0x08 onwards in their first
+	// byte take EncodedLenMax characters. Sortable XUIDs created from 2004
+	// to 2039, whose first byte is 0x01, all take 21 characters, and the nil
+	// UUID takes EncodedLenMin. Do not assume a fixed length.
+	EncodedLenMin = 16
+	EncodedLenMax = 22
+
+	// MaxPrefixLen is the maximum length of a prefix in bytes.
+	MaxPrefixLen = 128
+
+	// LengthMarker ends the prefix length in EncodingLengthPrefixed.
+	LengthMarker = ":"
+
+	// MaxStringLen is the maximum length of the string form of any XUID
+	// in EncodingDefault.
+	MaxStringLen = MaxPrefixLen + len(Separator) + EncodedLenMax
+)
+
+// Base58Alphabet is the alphabet of the payload, in digit order.
+const Base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// StringLen returns the maximum length of the string form of a XUID with
+// the given prefix in the configured encoding, which is suitable for sizing
+// VARCHAR columns.
+func StringLen(prefix string) int {
+	if prefix == "" {
+		return EncodedLenMax
+	}
+	n := len(prefix) + len(Separator) + EncodedLenMax
+	if GetConfig().Encoding == EncodingLengthPrefixed {
+		n += len(strconv.Itoa(len(prefix))) + len(LengthMarker)
+	}
+	return n
+}
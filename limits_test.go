@@ -0,0 +1,88 @@
+package xuid_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodedLen(t *testing.T) {
+	t.Run("payload of generated XUIDs is within bounds", func(t *testing.T) {
+		for i := 0; i < 1000; i++ {
+			n := len(xuid.MustNewRandom("").String())
+
+			assert.GreaterOrEqual(t, n, xuid.EncodedLenMin)
+			assert.LessOrEqual(t, n, xuid.EncodedLenMax)
+		}
+	})
+
+	t.Run("sortable payloads of this era are shorter than EncodedLenMax", func(t *testing.T) {
+		at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < 100; i++ {
+			assert.Len(t, xuid.Must(xuid.NewSortableAt("", at)).String(), xuid.EncodedLenMax-1)
+		}
+	})
+
+	t.Run("max UUID encodes to EncodedLenMax", func(t *testing.T) {
+		id, _ := xuid.NewWith(uuid.Max, "")
+
+		assert.Len(t, id.String(), xuid.EncodedLenMax)
+	})
+
+	t.Run("nil UUID encodes to EncodedLenMin", func(t *testing.T) {
+		id, _ := xuid.NilUUID()
+
+		assert.Len(t, id.String(), xuid.EncodedLenMin)
+	})
+}
+
+func TestStringLen(t *testing.T) {
+	t.Run("bounds string length for prefix", func(t *testing.T) {
+		id, _ := xuid.NewWith(uuid.Max, "user")
+
+		assert.Equal(t, len(id.String()), xuid.StringLen("user"))
+	})
+
+	t.Run("omits separator without prefix", func(t *testing.T) {
+		assert.Equal(t, xuid.EncodedLenMax, xuid.StringLen(""))
+	})
+
+	t.Run("MaxStringLen bounds longest prefix", func(t *testing.T) {
+		assert.Equal(t, xuid.MaxStringLen, xuid.StringLen(strings.Repeat("a", xuid.MaxPrefixLen)))
+	})
+}
+
+func TestMaxPrefixLen(t *testing.T) {
+	t.Run("accepts prefix of MaxPrefixLen", func(t *testing.T) {
+		_, err := xuid.NewSortable(strings.Repeat("a", xuid.MaxPrefixLen))
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects longer prefix in constructors", func(t *testing.T) {
+		prefix := strings.Repeat("a", xuid.MaxPrefixLen+1)
+
+		_, err := xuid.NewSortable(prefix)
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+
+		_, err = xuid.NewRandom(prefix)
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+
+		_, err = xuid.NewWith(uuid.New(), prefix)
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+	})
+
+	t.Run("rejects longer prefix in Parse", func(t *testing.T) {
+		id := xuid.MustNewSortable("")
+
+		_, err := xuid.Parse(strings.Repeat("a", xuid.MaxPrefixLen+1) + "_" + id.String())
+
+		require.ErrorIs(t, err, xuid.ErrParse)
+		assert.Contains(t, err.Error(), "prefix is too long")
+	})
+}
@@ -0,0 +1,261 @@
+package xuid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMaxClockWait is the longest a generator waits for the clock under
+// ClockWait, unless changed with SetMaxClockWait.
+const DefaultMaxClockWait = time.Second
+
+// monotonicSeqMax is the largest value of the 12-bit counter stored in the
+// rand_a field of UUIDv7.
+const monotonicSeqMax = 0xfff
+
+// Monotonic generates sortable XUIDs that are strictly increasing, even
+// when several are created in the same millisecond. The UUIDs follow method
+// 1 of RFC 9562: a 12-bit counter, reset every millisecond, is stored after
+// the timestamp and followed by 62 random bits. When the counter is
+// exhausted the timestamp is advanced by one millisecond, so IDs may run
+// slightly ahead of the clock under heavy load.
+//
+// A Monotonic is safe for concurrent use.
+type Monotonic struct {
+	prefix string
+	store  MonotonicStore
+
+	mu      sync.Mutex
+	ms      int64
+	seq     uint16
+	saved   int64
+	last    XUID
+	lastNow time.Time
+	policy  ClockPolicy
+	maxWait time.Duration
+	onSkew  func(ClockSkew)
+	clock   func() time.Time
+	offset  time.Duration
+
+	stats monotonicCounters
+}
+
+// ClockPolicy selects how a Monotonic generator handles a wall clock that
+// went backwards since the previous call.
+type ClockPolicy int
+
+const (
+	// ClockReuse keeps issuing IDs after the last timestamp, incrementing
+	// the counter, until the clock catches up.
+	ClockReuse ClockPolicy = iota
+	// ClockWait sleeps until the clock is back at the last observed time,
+	// without blocking other callers meanwhile. Regressions longer than the
+	// maximum wait, such as after a VM restore, fail with
+	// ErrClockRegression instead of blocking callers for as long.
+	ClockWait
+	// ClockError fails with ErrClockRegression.
+	ClockError
+)
+
+// ClockSkew describes a backwards jump of the wall clock.
+type ClockSkew struct {
+	// Last is the time observed by the previous call and Now the current
+	// time, which is before Last.
+	Last time.Time
+	Now  time.Time
+}
+
+// SetClockPolicy sets how m handles the wall clock going backwards. The
+// default is ClockReuse.
+func (m *Monotonic) SetClockPolicy(p ClockPolicy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.policy = p
+}
+
+// SetMaxClockWait sets the longest m waits for the clock under ClockWait.
+// Zero, the default, selects DefaultMaxClockWait.
+func (m *Monotonic) SetMaxClockWait(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxWait = d
+}
+
+// OnClockSkew sets a function called, whatever the policy, every time m
+// observes the wall clock going backwards, for logging and alerting.
+// f is called while m is locked and must not use m.
+func (m *Monotonic) OnClockSkew(f func(ClockSkew)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onSkew = f
+}
+
+// SetClock replaces the time source of m, which defaults to time.Now.
+func (m *Monotonic) SetClock(now func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clock = now
+}
+
+// SetClockOffset makes m add d to the times returned by its time source,
+// to compensate for a known clock error.
+func (m *Monotonic) SetClockOffset(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.offset = d
+}
+
+// NewMonotonic returns a generator of XUIDs with prefix.
+func NewMonotonic(prefix string) (*Monotonic, error) {
+	return NewMonotonicWithStore(prefix, nil)
+}
+
+// NewMonotonicWithStore returns a generator of XUIDs with prefix whose
+// state survives restarts in store, so that IDs issued after a restart sort
+// after those issued before, even if the clock moved backwards meanwhile.
+//
+// The saved state is an upper bound of the issued IDs, MonotonicSaveAhead
+// ahead of the last one, so the store is written at most once per
+// MonotonicSaveAhead. After a restart, IDs may therefore run up to
+// MonotonicSaveAhead ahead of the clock until it catches up.
+func NewMonotonicWithStore(prefix string, store MonotonicStore) (*Monotonic, error) {
+	if _, err := NewWith(uuid.Nil, prefix); err != nil {
+		return nil, err
+	}
+	m := &Monotonic{prefix: prefix, store: store}
+	if store != nil {
+		state, err := store.Load()
+		if err != nil {
+			return nil, err
+		}
+		m.ms, m.seq, m.saved = state.Millis, state.Seq, state.Millis
+	}
+	return m, nil
+}
+
+// New returns the next XUID.
+func (m *Monotonic) New() (XUID, error) {
+	ids, err := m.Reserve(1)
+	if err != nil {
+		return XUID{}, err
+	}
+	return ids[0], nil
+}
+
+// Reserve returns n consecutive XUIDs in increasing order. No other XUID
+// issued by m sorts between them, so batch writers can assign ordered IDs
+// to rows before building an insert statement.
+func (m *Monotonic) Reserve(n int) ([]XUID, error) {
+	if n < 0 {
+		return nil, ErrNegativeCount
+	}
+	if n == 0 {
+		return []XUID{}, nil
+	}
+	random := make([]byte, 8*n)
+	if _, err := rand.Read(random); err != nil {
+		m.stats.entropyFailures.Add(1)
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now, err := m.now()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]XUID, n)
+	for i := range res {
+		m.advance(now)
+		res[i] = XUID{uuid: monotonicUUID(m.ms, m.seq, random[8*i:8*i+8]), prefix: m.prefix}
+	}
+	if m.store != nil && m.ms >= m.saved {
+		saved := m.ms + MonotonicSaveAhead.Milliseconds()
+		if err := m.store.Save(MonotonicState{Millis: saved}); err != nil {
+			return nil, err
+		}
+		m.saved = saved
+	}
+	m.last = res[n-1]
+	m.stats.generated.Add(uint64(n))
+	return res, nil
+}
+
+// LastIssued returns the last XUID issued by m, or a nil XUID if none was
+// issued yet.
+func (m *Monotonic) LastIssued() XUID {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last
+}
+
+// now returns the current time in milliseconds, applying the clock policy
+// if the clock went backwards. m.mu must be held; it is released while
+// waiting under ClockWait.
+func (m *Monotonic) now() (int64, error) {
+	clock := m.clock
+	if clock == nil {
+		clock = time.Now
+	}
+	now := clock().Add(m.offset)
+	if now.UnixMilli() < m.lastNow.UnixMilli() {
+		m.stats.clockRegressions.Add(1)
+		if m.onSkew != nil {
+			m.onSkew(ClockSkew{Last: m.lastNow, Now: now})
+		}
+		switch m.policy {
+		case ClockWait:
+			wait, maxWait := m.lastNow.Sub(now), m.maxWait
+			if maxWait == 0 {
+				maxWait = DefaultMaxClockWait
+			}
+			if wait > maxWait {
+				return 0, fmt.Errorf("%w: by %v, more than the maximum wait of %v", ErrClockRegression, wait, maxWait)
+			}
+			m.stats.clockWaits.Add(1)
+			m.mu.Unlock()
+			time.Sleep(wait)
+			m.mu.Lock()
+			if now = clock().Add(m.offset); now.Before(m.lastNow) {
+				now = m.lastNow
+			}
+		case ClockError:
+			return 0, ErrClockRegression
+		}
+	}
+	if now.After(m.lastNow) {
+		m.lastNow = now
+	}
+	return now.UnixMilli(), nil
+}
+
+// advance moves the generator state past the last issued XUID. m.mu must be
+// held.
+func (m *Monotonic) advance(now int64) {
+	if now > m.ms {
+		m.ms, m.seq = now, 0
+		return
+	}
+	if m.seq < monotonicSeqMax {
+		m.seq++
+		return
+	}
+	m.stats.sequenceOverflows.Add(1)
+	m.ms, m.seq = m.ms+1, 0
+}
+
+func monotonicUUID(ms int64, seq uint16, random []byte) uuid.UUID {
+	var id uuid.UUID
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(ms))
+	copy(id[:6], b[2:])
+	binary.BigEndian.PutUint16(id[6:8], 0x7000|seq) // version 7
+	copy(id[8:], random)
+	id[8] = (id[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return id
+}
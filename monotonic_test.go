@@ -0,0 +1,195 @@
+package xuid_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonotonic(t *testing.T) {
+	t.Run("generates strictly increasing sortable XUIDs", func(t *testing.T) {
+		m, err := xuid.NewMonotonic("evt")
+		require.NoError(t, err)
+
+		prev := xuid.XUID{}
+		for i := 0; i < 10000; i++ {
+			x, err := m.New()
+			require.NoError(t, err)
+
+			assert.True(t, x.IsSortable())
+			assert.Equal(t, uuid.RFC4122, x.GetUUID().Variant())
+			assert.Equal(t, "evt", x.GetPrefix())
+			require.Equal(t, -1, xuid.Compare(prev, x))
+			prev = x
+		}
+	})
+
+	t.Run("reserves contiguous ordered blocks", func(t *testing.T) {
+		m, _ := xuid.NewMonotonic("row")
+
+		ids, err := m.Reserve(100)
+		require.NoError(t, err)
+		next, err := m.New()
+		require.NoError(t, err)
+
+		require.Len(t, ids, 100)
+		for i := 1; i < len(ids); i++ {
+			assert.Equal(t, -1, xuid.Compare(ids[i-1], ids[i]))
+		}
+		assert.Equal(t, -1, xuid.Compare(ids[99], next))
+	})
+
+	t.Run("reports the last issued XUID", func(t *testing.T) {
+		m, _ := xuid.NewMonotonic("row")
+		assert.True(t, xuid.IsEmpty(m.LastIssued()))
+
+		ids, _ := m.Reserve(3)
+
+		assert.Equal(t, ids[2], m.LastIssued())
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		m, _ := xuid.NewMonotonic("")
+		var (
+			mu   sync.Mutex
+			seen = map[xuid.XUID]bool{}
+			wg   sync.WaitGroup
+		)
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 1000; i++ {
+					x, _ := m.New()
+					mu.Lock()
+					seen[x] = true
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Len(t, seen, 8000)
+	})
+
+	t.Run("rejects invalid arguments", func(t *testing.T) {
+		_, err := xuid.NewMonotonic(strings.Repeat("a", xuid.MaxPrefixLen+1))
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+
+		m, _ := xuid.NewMonotonic("")
+		_, err = m.Reserve(-1)
+		assert.ErrorIs(t, err, xuid.ErrNegativeCount)
+	})
+}
+
+func TestMonotonicClockPolicy(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newGen := func(policy xuid.ClockPolicy) (*xuid.Monotonic, *time.Time, *[]xuid.ClockSkew) {
+		m, _ := xuid.NewMonotonic("evt")
+		now := start
+		var skews []xuid.ClockSkew
+		m.SetClock(func() time.Time { return now })
+		m.SetClockPolicy(policy)
+		m.OnClockSkew(func(s xuid.ClockSkew) { skews = append(skews, s) })
+		return m, &now, &skews
+	}
+
+	t.Run("reuses the last timestamp by default", func(t *testing.T) {
+		m, now, skews := newGen(xuid.ClockReuse)
+		first, _ := m.New()
+		*now = start.Add(-time.Second)
+
+		second, err := m.New()
+
+		require.NoError(t, err)
+		assert.Equal(t, -1, xuid.Compare(first, second))
+		assert.Equal(t, []xuid.ClockSkew{{Last: start, Now: start.Add(-time.Second)}}, *skews)
+	})
+
+	t.Run("fails with ClockError", func(t *testing.T) {
+		m, now, skews := newGen(xuid.ClockError)
+		_, _ = m.New()
+		*now = start.Add(-time.Second)
+
+		_, err := m.New()
+
+		assert.ErrorIs(t, err, xuid.ErrClockRegression)
+		assert.Len(t, *skews, 1)
+	})
+
+	t.Run("waits with ClockWait", func(t *testing.T) {
+		m, now, _ := newGen(xuid.ClockWait)
+		first, _ := m.New()
+		*now = start.Add(-20 * time.Millisecond)
+
+		began := time.Now()
+		second, err := m.New()
+
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(began), 20*time.Millisecond)
+		assert.Equal(t, -1, xuid.Compare(first, second))
+	})
+
+	t.Run("fails with ClockWait on large regressions", func(t *testing.T) {
+		m, now, skews := newGen(xuid.ClockWait)
+		_, _ = m.New()
+		*now = start.Add(-2 * time.Hour)
+
+		began := time.Now()
+		_, err := m.New()
+
+		assert.ErrorIs(t, err, xuid.ErrClockRegression)
+		assert.Less(t, time.Since(began), xuid.DefaultMaxClockWait)
+		assert.Len(t, *skews, 1)
+		assert.Zero(t, m.Stats().ClockWaits)
+	})
+
+	t.Run("applies the maximum wait set on the generator", func(t *testing.T) {
+		m, now, _ := newGen(xuid.ClockWait)
+		m.SetMaxClockWait(10 * time.Millisecond)
+		_, _ = m.New()
+		*now = start.Add(-20 * time.Millisecond)
+
+		_, err := m.New()
+
+		assert.ErrorIs(t, err, xuid.ErrClockRegression)
+	})
+
+	t.Run("does not block other callers while waiting", func(t *testing.T) {
+		m, now, _ := newGen(xuid.ClockWait)
+		first, _ := m.New()
+		*now = start.Add(-200 * time.Millisecond)
+		done := make(chan error)
+		go func() {
+			_, err := m.New()
+			done <- err
+		}()
+		for m.Stats().ClockWaits == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		began := time.Now()
+		last := m.LastIssued()
+
+		assert.Less(t, time.Since(began), 100*time.Millisecond)
+		assert.Equal(t, first, last)
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("does not report forward jumps", func(t *testing.T) {
+		m, now, skews := newGen(xuid.ClockError)
+		_, _ = m.New()
+		*now = start.Add(time.Hour)
+
+		_, err := m.New()
+
+		require.NoError(t, err)
+		assert.Empty(t, *skews)
+	})
+}
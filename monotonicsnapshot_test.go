@@ -0,0 +1,83 @@
+package xuid_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonotonicSnapshot(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newGen := func() *xuid.Monotonic {
+		m, _ := xuid.NewMonotonic("evt")
+		m.SetClock(func() time.Time { return start })
+		m.SetClockPolicy(xuid.ClockError)
+		m.SetClockOffset(-5 * time.Millisecond)
+		return m
+	}
+
+	t.Run("captures configuration and state", func(t *testing.T) {
+		m := newGen()
+		last, _ := m.New()
+
+		s := m.Snapshot()
+
+		assert.Equal(t, 7, s.Version)
+		assert.Equal(t, "evt", s.Prefix)
+		assert.Equal(t, xuid.Base58Alphabet, s.Alphabet)
+		assert.Equal(t, xuid.ClockError, s.ClockPolicy)
+		assert.Equal(t, -5*time.Millisecond, s.ClockOffset)
+		assert.True(t, s.CustomClock)
+		assert.Equal(t, xuid.MonotonicState{Millis: start.UnixMilli() - 5}, s.State)
+		assert.Equal(t, &last, s.LastIssued)
+	})
+
+	t.Run("restores an equivalent generator through JSON", func(t *testing.T) {
+		m := newGen()
+		_, _ = m.Reserve(3)
+		data, err := json.Marshal(m.Snapshot())
+		require.NoError(t, err)
+
+		var s xuid.MonotonicSnapshot
+		require.NoError(t, json.Unmarshal(data, &s))
+		restored, err := xuid.RestoreMonotonic(s)
+		require.NoError(t, err)
+		restored.SetClock(func() time.Time { return start })
+
+		assert.Equal(t, m.Snapshot(), restored.Snapshot())
+		want, _ := m.New()
+		got, _ := restored.New()
+		assert.Equal(t, want.GetUUID().String()[:19], got.GetUUID().String()[:19])
+	})
+
+	t.Run("rejects incompatible snapshots", func(t *testing.T) {
+		s := newGen().Snapshot()
+		s.Version = 4
+
+		_, err := xuid.RestoreMonotonic(s)
+
+		assert.ErrorIs(t, err, xuid.ErrIncompatibleSnapshot)
+	})
+
+	t.Run("rejects snapshots of another encoding", func(t *testing.T) {
+		s := newGen().Snapshot()
+		withConfig(t, xuid.Config{Encoding: xuid.EncodingLengthPrefixed})
+
+		_, err := xuid.RestoreMonotonic(s)
+
+		assert.ErrorIs(t, err, xuid.ErrIncompatibleSnapshot)
+	})
+
+	t.Run("omits the last XUID of unused generators", func(t *testing.T) {
+		m, _ := xuid.NewMonotonic("evt")
+
+		data, err := json.Marshal(m.Snapshot())
+
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "last_issued")
+	})
+}
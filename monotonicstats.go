@@ -0,0 +1,52 @@
+package xuid
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+// MonotonicStats holds the counters of a Monotonic generator since it was
+// created, for alerting on generation anomalies.
+type MonotonicStats struct {
+	// Generated is the number of XUIDs issued.
+	Generated uint64
+	// EntropyFailures is the number of failures to read random bytes.
+	EntropyFailures uint64
+	// ClockRegressions is the number of times the clock went backwards.
+	ClockRegressions uint64
+	// ClockWaits is the number of times the generator slept for the clock
+	// to catch up, under ClockWait.
+	ClockWaits uint64
+	// SequenceOverflows is the number of times the 12-bit counter was
+	// exhausted and the timestamp advanced ahead of the clock.
+	SequenceOverflows uint64
+}
+
+type monotonicCounters struct {
+	generated         atomic.Uint64
+	entropyFailures   atomic.Uint64
+	clockRegressions  atomic.Uint64
+	clockWaits        atomic.Uint64
+	sequenceOverflows atomic.Uint64
+}
+
+// Stats returns the counters of m. Counters only increase, so they map to
+// Prometheus counters, such as with
+//
+//	prometheus.NewCounterFunc(prometheus.CounterOpts{Name: "xuid_generated_total"},
+//		func() float64 { return float64(m.Stats().Generated) })
+func (m *Monotonic) Stats() MonotonicStats {
+	return MonotonicStats{
+		Generated:         m.stats.generated.Load(),
+		EntropyFailures:   m.stats.entropyFailures.Load(),
+		ClockRegressions:  m.stats.clockRegressions.Load(),
+		ClockWaits:        m.stats.clockWaits.Load(),
+		SequenceOverflows: m.stats.sequenceOverflows.Load(),
+	}
+}
+
+// PublishExpvar publishes the counters of m as the expvar variable name.
+// Like expvar.Publish, it panics if name is already in use.
+func (m *Monotonic) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return m.Stats() }))
+}
@@ -0,0 +1,68 @@
+package xuid_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expvarRuns makes expvar names unique across runs of the same test, as
+// with go test -count, since the expvar registry is process-global.
+var expvarRuns atomic.Int64
+
+func TestMonotonicStats(t *testing.T) {
+	t.Run("counts generated XUIDs and overflows", func(t *testing.T) {
+		m, _ := xuid.NewMonotonic("evt")
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		m.SetClock(func() time.Time { return now })
+
+		_, err := m.Reserve(5000)
+		require.NoError(t, err)
+		_, err = m.New()
+		require.NoError(t, err)
+
+		stats := m.Stats()
+		assert.EqualValues(t, 5001, stats.Generated)
+		assert.EqualValues(t, 1, stats.SequenceOverflows)
+		assert.Zero(t, stats.ClockRegressions)
+	})
+
+	t.Run("counts clock regressions and waits", func(t *testing.T) {
+		m, _ := xuid.NewMonotonic("evt")
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		now := start
+		m.SetClock(func() time.Time { return now })
+		_, _ = m.New()
+		now = start.Add(-time.Second)
+		_, _ = m.New()
+
+		m.SetClockPolicy(xuid.ClockWait)
+		now = start.Add(-time.Millisecond)
+		_, _ = m.New()
+
+		stats := m.Stats()
+		assert.EqualValues(t, 2, stats.ClockRegressions)
+		assert.EqualValues(t, 1, stats.ClockWaits)
+		assert.EqualValues(t, 3, stats.Generated)
+	})
+
+	t.Run("publishes counters with expvar", func(t *testing.T) {
+		m, _ := xuid.NewMonotonic("evt")
+		_, _ = m.Reserve(3)
+
+		name := fmt.Sprintf("%s_%d", t.Name(), expvarRuns.Add(1))
+
+		m.PublishExpvar(name)
+
+		var stats xuid.MonotonicStats
+		require.NoError(t, json.Unmarshal([]byte(expvar.Get(name).String()), &stats))
+		assert.EqualValues(t, 3, stats.Generated)
+	})
+}
@@ -0,0 +1,74 @@
+package xuid
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MonotonicSaveAhead is how far ahead of the last issued ID a Monotonic
+// generator saves its state.
+const MonotonicSaveAhead = time.Second
+
+// MonotonicState is the position of a Monotonic generator: the next XUID
+// it issues sorts after every XUID with timestamp Millis and counter Seq.
+type MonotonicState struct {
+	Millis int64  `json:"millis"`
+	Seq    uint16 `json:"seq"`
+}
+
+// MonotonicStore persists the state of a Monotonic generator. Stores backed
+// by shared systems, such as a Redis key per generator, only need to
+// implement these two methods.
+type MonotonicStore interface {
+	// Load returns the saved state, or the zero state if none was saved.
+	Load() (MonotonicState, error)
+	// Save replaces the saved state.
+	Save(MonotonicState) error
+}
+
+// MonotonicFileStore is a MonotonicStore keeping the state as JSON in a
+// file. The file is replaced atomically on every save.
+type MonotonicFileStore struct {
+	Path string
+}
+
+func (s MonotonicFileStore) Load() (MonotonicState, error) {
+	var state MonotonicState
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(data, &state)
+	return state, err
+}
+
+func (s MonotonicFileStore) Save(state MonotonicState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.Path)
+}
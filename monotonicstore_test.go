@@ -0,0 +1,84 @@
+package xuid_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore struct {
+	state xuid.MonotonicState
+	saves int
+	err   error
+}
+
+func (s *memoryStore) Load() (xuid.MonotonicState, error) { return s.state, nil }
+
+func (s *memoryStore) Save(state xuid.MonotonicState) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.state = state
+	s.saves++
+	return nil
+}
+
+func TestMonotonicWithStore(t *testing.T) {
+	t.Run("issues IDs after the restored state", func(t *testing.T) {
+		future := time.Now().Add(time.Hour).UnixMilli()
+		store := &memoryStore{state: xuid.MonotonicState{Millis: future, Seq: 7}}
+		m, err := xuid.NewMonotonicWithStore("evt", store)
+		require.NoError(t, err)
+
+		x, err := m.New()
+		require.NoError(t, err)
+
+		info, err := xuid.InspectString(x.String())
+		require.NoError(t, err)
+		assert.Equal(t, future, info.Time.UnixMilli())
+		assert.Equal(t, uint16(0x7008), uint16(x.GetUUID()[6])<<8|uint16(x.GetUUID()[7]))
+	})
+
+	t.Run("saves ahead of issued IDs", func(t *testing.T) {
+		store := &memoryStore{}
+		m, _ := xuid.NewMonotonicWithStore("evt", store)
+
+		for i := 0; i < 100; i++ {
+			_, err := m.New()
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 1, store.saves)
+		info, _ := xuid.InspectString(m.LastIssued().String())
+		assert.Greater(t, store.state.Millis, info.Time.UnixMilli())
+	})
+
+	t.Run("survives restarts through a file", func(t *testing.T) {
+		store := xuid.MonotonicFileStore{Path: filepath.Join(t.TempDir(), "state.json")}
+		m1, err := xuid.NewMonotonicWithStore("evt", store)
+		require.NoError(t, err)
+		before, err := m1.New()
+		require.NoError(t, err)
+
+		m2, err := xuid.NewMonotonicWithStore("evt", store)
+		require.NoError(t, err)
+		after, err := m2.New()
+		require.NoError(t, err)
+
+		assert.Equal(t, -1, xuid.Compare(before, after))
+	})
+
+	t.Run("fails when the state cannot be saved", func(t *testing.T) {
+		store := &memoryStore{err: errors.New("unavailable")}
+		m, _ := xuid.NewMonotonicWithStore("evt", store)
+
+		_, err := m.New()
+
+		assert.ErrorIs(t, err, store.err)
+	})
+}
@@ -0,0 +1,33 @@
+package xuid
+
+import "github.com/google/uuid"
+
+// Well-known namespaces from RFC 4122 for deterministic identifiers.
+var (
+	NamespaceDNS  = uuid.NameSpaceDNS
+	NamespaceURL  = uuid.NameSpaceURL
+	NamespaceOID  = uuid.NameSpaceOID
+	NamespaceX500 = uuid.NameSpaceX500
+)
+
+// NamespaceXUID is the root namespace of application namespaces created by
+// NamespaceFor. It is derived from NamespaceURL and the module path, and
+// never changes.
+var NamespaceXUID = uuid.NewSHA1(NamespaceURL, []byte("https://github.com/47monad/xuid"))
+
+// NamespaceFor derives the namespace of an application or service.
+// The same name always yields the same namespace, so services sharing a name
+// generate matching deterministic identifiers.
+func NamespaceFor(name string) uuid.UUID {
+	return uuid.NewSHA1(NamespaceXUID, []byte(name))
+}
+
+// NewDeterministic creates a XUID holding a UUIDv5 derived from namespace
+// and name. Calling it twice with the same arguments yields equal XUIDs.
+func NewDeterministic(namespace uuid.UUID, name string, prefix string) (XUID, error) {
+	return NewWith(uuid.NewSHA1(namespace, []byte(name)), prefix)
+}
+
+func MustNewDeterministic(namespace uuid.UUID, name string, prefix string) XUID {
+	return Must(NewDeterministic(namespace, name, prefix))
+}
@@ -0,0 +1,56 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaces(t *testing.T) {
+	t.Run("exposes RFC 4122 namespaces", func(t *testing.T) {
+		assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", xuid.NamespaceDNS.String())
+		assert.Equal(t, "6ba7b811-9dad-11d1-80b4-00c04fd430c8", xuid.NamespaceURL.String())
+		assert.Equal(t, "6ba7b812-9dad-11d1-80b4-00c04fd430c8", xuid.NamespaceOID.String())
+		assert.Equal(t, "6ba7b814-9dad-11d1-80b4-00c04fd430c8", xuid.NamespaceX500.String())
+	})
+
+	t.Run("NamespaceFor is stable", func(t *testing.T) {
+		assert.Equal(t, xuid.NamespaceFor("myapp"), xuid.NamespaceFor("myapp"))
+		assert.Equal(t, uuid.Version(5), xuid.NamespaceFor("myapp").Version())
+	})
+
+	t.Run("NamespaceFor differs per name", func(t *testing.T) {
+		assert.NotEqual(t, xuid.NamespaceFor("myapp"), xuid.NamespaceFor("otherapp"))
+	})
+}
+
+func TestNewDeterministic(t *testing.T) {
+	t.Run("creates equal XUIDs for equal input", func(t *testing.T) {
+		ns := xuid.NamespaceFor("myapp")
+
+		id1, err1 := xuid.NewDeterministic(ns, "alice@example.com", "user")
+		id2, err2 := xuid.NewDeterministic(ns, "alice@example.com", "user")
+
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.True(t, id1.Equal(id2))
+		assert.Equal(t, "user", id1.GetPrefix())
+		assert.Equal(t, uuid.Version(5), id1.GetUUID().Version())
+	})
+
+	t.Run("matches uuid.NewSHA1", func(t *testing.T) {
+		id := xuid.MustNewDeterministic(xuid.NamespaceDNS, "example.com", "")
+
+		assert.Equal(t, uuid.NewSHA1(uuid.NameSpaceDNS, []byte("example.com")), id.GetUUID())
+	})
+
+	t.Run("differs between namespaces", func(t *testing.T) {
+		id1 := xuid.MustNewDeterministic(xuid.NamespaceFor("a"), "name", "user")
+		id2 := xuid.MustNewDeterministic(xuid.NamespaceFor("b"), "name", "user")
+
+		assert.False(t, id1.Equal(id2))
+	})
+}
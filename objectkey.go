@@ -0,0 +1,69 @@
+package xuid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ObjectKey expands the tokens of layout to build object storage keys, such
+// as "{prefix}/{yyyy}/{mm}/{dd}/{id}" for "user/2023/06/21/user_...".
+// Supported tokens are:
+//
+//	{id}      the string form
+//	{prefix}  the prefix
+//	{payload} the base58 payload
+//	{uuid}    the hyphenated UUID
+//	{yyyy} {mm} {dd} {hh}  the UTC creation time of sortable XUIDs
+//
+// Time tokens fail with ErrNotSortable for other versions, and unknown or
+// unterminated tokens fail with ErrInvalidLayout.
+func (x XUID) ObjectKey(layout string) (string, error) {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(layout, '{')
+		if start < 0 {
+			b.WriteString(layout)
+			return b.String(), nil
+		}
+		end := strings.IndexByte(layout[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("%w: unterminated token in %q", ErrInvalidLayout, layout)
+		}
+		b.WriteString(layout[:start])
+		token := layout[start+1 : start+end]
+		v, err := x.objectKeyToken(token)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(v)
+		layout = layout[start+end+1:]
+	}
+}
+
+func (x XUID) objectKeyToken(token string) (string, error) {
+	switch token {
+	case "id":
+		return x.String(), nil
+	case "prefix":
+		return x.prefix, nil
+	case "payload":
+		return x.payload(), nil
+	case "uuid":
+		return x.uuid.String(), nil
+	case "yyyy", "mm", "dd", "hh":
+		if !x.IsSortable() {
+			return "", fmt.Errorf("%w: {%s} requires a sortable XUID", ErrNotSortable, token)
+		}
+		t := v7Time(x.uuid).UTC()
+		switch token {
+		case "yyyy":
+			return fmt.Sprintf("%04d", t.Year()), nil
+		case "mm":
+			return fmt.Sprintf("%02d", t.Month()), nil
+		case "dd":
+			return fmt.Sprintf("%02d", t.Day()), nil
+		}
+		return fmt.Sprintf("%02d", t.Hour()), nil
+	}
+	return "", fmt.Errorf("%w: unknown token {%s}", ErrInvalidLayout, token)
+}
@@ -0,0 +1,57 @@
+package xuid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectKey(t *testing.T) {
+	created := time.Date(2023, time.June, 1, 7, 30, 0, 0, time.UTC)
+	x := xuid.Must(xuid.NewSortableAt("user", created))
+
+	t.Run("expands time tokens in UTC", func(t *testing.T) {
+		key, err := x.ObjectKey("{prefix}/{yyyy}/{mm}/{dd}/{hh}/{id}")
+
+		require.NoError(t, err)
+		assert.Equal(t, "user/2023/06/01/07/"+x.String(), key)
+	})
+
+	t.Run("expands identifier tokens", func(t *testing.T) {
+		key, err := x.ObjectKey("exports/{uuid}.json")
+		require.NoError(t, err)
+		assert.Equal(t, "exports/"+x.GetUUID().String()+".json", key)
+
+		key, err = x.ObjectKey("{payload}")
+		require.NoError(t, err)
+		assert.Equal(t, xuid.Must(xuid.NewWith(x.GetUUID(), "")).String(), key)
+	})
+
+	t.Run("returns layout without tokens unchanged", func(t *testing.T) {
+		key, err := x.ObjectKey("static/key")
+
+		require.NoError(t, err)
+		assert.Equal(t, "static/key", key)
+	})
+
+	t.Run("time tokens require sortable XUIDs", func(t *testing.T) {
+		random := xuid.MustNewRandom("user")
+
+		_, err := random.ObjectKey("{yyyy}/{id}")
+		assert.ErrorIs(t, err, xuid.ErrNotSortable)
+
+		_, err = random.ObjectKey("{prefix}/{id}")
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects invalid layouts", func(t *testing.T) {
+		for _, layout := range []string{"{unknown}", "{id", "{}", "a/{yyyy"} {
+			_, err := x.ObjectKey(layout)
+
+			assert.ErrorIs(t, err, xuid.ErrInvalidLayout, layout)
+		}
+	})
+}
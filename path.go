@@ -0,0 +1,78 @@
+package xuid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PathSeparator separates the XUIDs of compound references.
+const PathSeparator = "/"
+
+// ParsePath parses a compound reference made of XUIDs separated by
+// PathSeparator, such as "org_abc/user_def", which encodes an ownership
+// chain. Every segment must be a valid XUID; errors report the index of
+// the first invalid segment. Prefixes containing PathSeparator cannot be
+// used in compound references.
+func ParsePath(s string) ([]XUID, error) {
+	segments := strings.Split(s, PathSeparator)
+	res := make([]XUID, len(segments))
+	for i, seg := range segments {
+		if seg == "" {
+			return nil, fmt.Errorf("%w: path segment %d is empty", ErrParse, i)
+		}
+		if err := ParseInto(&res[i], seg); err != nil {
+			return nil, fmt.Errorf("path segment %d (%q): %w", i, seg, err)
+		}
+	}
+	return res, nil
+}
+
+// BuildPath returns the compound reference of ids, the inverse of
+// ParsePath.
+func BuildPath(ids ...XUID) string {
+	var b []byte
+	for i, id := range ids {
+		if i > 0 {
+			b = append(b, PathSeparator...)
+		}
+		b = id.appendString(b)
+	}
+	return string(b)
+}
+
+// ExpandPath replaces the placeholders of template, such as
+// "/orgs/{org}/users/{user}", with the string forms of ids, in order. Each
+// placeholder names the prefix of the XUID that replaces it, so IDs of
+// different entity types cannot be transposed: a mismatched prefix, a
+// different number of placeholders and ids, or a malformed template fail
+// with ErrPathTemplate.
+func ExpandPath(template string, ids ...XUID) (string, error) {
+	var b strings.Builder
+	n := 0
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("%w: unterminated placeholder", ErrPathTemplate)
+		}
+		name := template[start+1 : start+end]
+		if n >= len(ids) {
+			return "", fmt.Errorf("%w: no XUID for placeholder {%s}", ErrPathTemplate, name)
+		}
+		if ids[n].prefix != name {
+			return "", fmt.Errorf("%w: placeholder {%s} got a XUID with prefix %q", ErrPathTemplate, name, ids[n].prefix)
+		}
+		b.WriteString(template[:start])
+		b.WriteString(ids[n].String())
+		template = template[start+end+1:]
+		n++
+	}
+	if n != len(ids) {
+		return "", fmt.Errorf("%w: %d placeholders for %d XUIDs", ErrPathTemplate, n, len(ids))
+	}
+	b.WriteString(template)
+	return b.String(), nil
+}
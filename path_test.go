@@ -0,0 +1,86 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	org, user := xuid.MustNewSortable("org"), xuid.MustNewSortable("user")
+
+	t.Run("parses every segment", func(t *testing.T) {
+		ids, err := xuid.ParsePath(org.String() + "/" + user.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, []xuid.XUID{org, user}, ids)
+	})
+
+	t.Run("parses single XUIDs", func(t *testing.T) {
+		ids, err := xuid.ParsePath(org.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, []xuid.XUID{org}, ids)
+	})
+
+	t.Run("reports the invalid segment", func(t *testing.T) {
+		_, err := xuid.ParsePath(org.String() + "/user_0OIl")
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+		assert.Contains(t, err.Error(), "path segment 1")
+	})
+
+	t.Run("rejects empty segments", func(t *testing.T) {
+		for _, s := range []string{"", "/" + org.String(), org.String() + "/", org.String() + "//" + user.String()} {
+			_, err := xuid.ParsePath(s)
+
+			assert.ErrorIs(t, err, xuid.ErrParse, s)
+		}
+	})
+}
+
+func TestBuildPath(t *testing.T) {
+	org, user := xuid.MustNewSortable("org"), xuid.MustNewSortable("user")
+
+	path := xuid.BuildPath(org, user)
+
+	assert.Equal(t, org.String()+"/"+user.String(), path)
+	ids, err := xuid.ParsePath(path)
+	require.NoError(t, err)
+	assert.Equal(t, []xuid.XUID{org, user}, ids)
+	assert.Equal(t, "", xuid.BuildPath())
+}
+
+func TestExpandPath(t *testing.T) {
+	org, user := xuid.MustNewSortable("org"), xuid.MustNewSortable("user")
+	const template = "/orgs/{org}/users/{user}"
+
+	t.Run("expands placeholders in order", func(t *testing.T) {
+		path, err := xuid.ExpandPath(template, org, user)
+
+		require.NoError(t, err)
+		assert.Equal(t, "/orgs/"+org.String()+"/users/"+user.String(), path)
+	})
+
+	t.Run("rejects transposed IDs", func(t *testing.T) {
+		_, err := xuid.ExpandPath(template, user, org)
+
+		assert.ErrorIs(t, err, xuid.ErrPathTemplate)
+	})
+
+	t.Run("rejects a different number of IDs", func(t *testing.T) {
+		_, err := xuid.ExpandPath(template, org)
+		assert.ErrorIs(t, err, xuid.ErrPathTemplate)
+
+		_, err = xuid.ExpandPath(template, org, user, user)
+		assert.ErrorIs(t, err, xuid.ErrPathTemplate)
+	})
+
+	t.Run("rejects malformed templates", func(t *testing.T) {
+		_, err := xuid.ExpandPath("/orgs/{org", org)
+
+		assert.ErrorIs(t, err, xuid.ErrPathTemplate)
+	})
+}
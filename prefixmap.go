@@ -0,0 +1,106 @@
+package xuid
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// PrefixMap maps XUIDs to values of type T, grouped by prefix first and
+// UUID second, so that values can be processed per entity type, such as
+// one query per table. The zero value is not usable; create maps with
+// NewPrefixMap.
+type PrefixMap[T any] struct {
+	groups map[string]map[uuid.UUID]T
+	n      int
+}
+
+func NewPrefixMap[T any]() *PrefixMap[T] {
+	return &PrefixMap[T]{groups: map[string]map[uuid.UUID]T{}}
+}
+
+// Set associates v with x, replacing any previous value.
+func (m *PrefixMap[T]) Set(x XUID, v T) {
+	g, ok := m.groups[x.prefix]
+	if !ok {
+		g = map[uuid.UUID]T{}
+		m.groups[x.prefix] = g
+	}
+	if _, ok := g[x.uuid]; !ok {
+		m.n++
+	}
+	g[x.uuid] = v
+}
+
+// Get returns the value associated with x.
+func (m *PrefixMap[T]) Get(x XUID) (T, bool) {
+	v, ok := m.groups[x.prefix][x.uuid]
+	return v, ok
+}
+
+// Delete removes x from the map.
+func (m *PrefixMap[T]) Delete(x XUID) {
+	g, ok := m.groups[x.prefix]
+	if !ok {
+		return
+	}
+	if _, ok := g[x.uuid]; !ok {
+		return
+	}
+	delete(g, x.uuid)
+	m.n--
+	if len(g) == 0 {
+		delete(m.groups, x.prefix)
+	}
+}
+
+// Len returns the number of XUIDs in the map.
+func (m *PrefixMap[T]) Len() int {
+	return m.n
+}
+
+// Prefixes returns the prefixes of the XUIDs in the map, sorted.
+func (m *PrefixMap[T]) Prefixes() []string {
+	res := make([]string, 0, len(m.groups))
+	for p := range m.groups {
+		res = append(res, p)
+	}
+	slices.Sort(res)
+	return res
+}
+
+// UUIDs returns the UUIDs of the XUIDs with prefix, sorted, for use as
+// query arguments.
+func (m *PrefixMap[T]) UUIDs(prefix string) []uuid.UUID {
+	g := m.groups[prefix]
+	res := make([]uuid.UUID, 0, len(g))
+	for id := range g {
+		res = append(res, id)
+	}
+	slices.SortFunc(res, func(a, b uuid.UUID) int {
+		return Compare(XUID{uuid: a}, XUID{uuid: b})
+	})
+	return res
+}
+
+// RangePrefix calls f for each XUID with prefix and its value, in no
+// particular order, until f returns false.
+func (m *PrefixMap[T]) RangePrefix(prefix string, f func(x XUID, v T) bool) {
+	for id, v := range m.groups[prefix] {
+		if !f(XUID{uuid: id, prefix: prefix}, v) {
+			return
+		}
+	}
+}
+
+// Range calls f for each XUID and its value, grouped by prefix in the
+// order of Prefixes, until f returns false.
+func (m *PrefixMap[T]) Range(f func(x XUID, v T) bool) {
+	for _, p := range m.Prefixes() {
+		for id, v := range m.groups[p] {
+			if !f(XUID{uuid: id, prefix: p}, v) {
+				return
+			}
+		}
+	}
+}
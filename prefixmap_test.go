@@ -0,0 +1,79 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixMap(t *testing.T) {
+	user1, user2 := xuid.MustNewSortable("user"), xuid.MustNewSortable("user")
+	order := xuid.MustNewSortable("order")
+
+	newMap := func() *xuid.PrefixMap[int] {
+		m := xuid.NewPrefixMap[int]()
+		m.Set(user1, 1)
+		m.Set(user2, 2)
+		m.Set(order, 3)
+		return m
+	}
+
+	t.Run("sets and gets values", func(t *testing.T) {
+		m := newMap()
+		m.Set(user1, 10)
+
+		v, ok := m.Get(user1)
+		assert.True(t, ok)
+		assert.Equal(t, 10, v)
+		assert.Equal(t, 3, m.Len())
+		_, ok = m.Get(xuid.MustNewSortable("user"))
+		assert.False(t, ok)
+	})
+
+	t.Run("distinguishes prefixes", func(t *testing.T) {
+		m := newMap()
+		other, _ := xuid.NewWith(user1.GetUUID(), "member")
+
+		_, ok := m.Get(other)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("groups by prefix", func(t *testing.T) {
+		m := newMap()
+
+		assert.Equal(t, []string{"order", "user"}, m.Prefixes())
+		assert.Equal(t, []uuid.UUID{user1.GetUUID(), user2.GetUUID()}, m.UUIDs("user"))
+
+		seen := map[xuid.XUID]int{}
+		m.RangePrefix("user", func(x xuid.XUID, v int) bool {
+			seen[x] = v
+			return true
+		})
+		assert.Equal(t, map[xuid.XUID]int{user1: 1, user2: 2}, seen)
+	})
+
+	t.Run("ranges grouped by prefix", func(t *testing.T) {
+		m := newMap()
+
+		var prefixes []string
+		m.Range(func(x xuid.XUID, v int) bool {
+			prefixes = append(prefixes, x.GetPrefix())
+			return true
+		})
+
+		assert.Equal(t, []string{"order", "user", "user"}, prefixes)
+	})
+
+	t.Run("deletes values and empty groups", func(t *testing.T) {
+		m := newMap()
+
+		m.Delete(order)
+		m.Delete(order)
+
+		assert.Equal(t, 2, m.Len())
+		assert.Equal(t, []string{"user"}, m.Prefixes())
+	})
+}
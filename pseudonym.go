@@ -0,0 +1,26 @@
+package xuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// Pseudonymize returns a keyed, deterministic replacement for x, for
+// exporting datasets without exposing real identifiers. The UUID is replaced
+// by a UUIDv8 made of the HMAC-SHA256 of the UUID bytes under key, and the
+// prefix is kept. The same key always maps an ID to the same pseudonym, so
+// joins across exports keep working; without the key, pseudonyms cannot be
+// linked back to the original IDs.
+//
+// Nil and tombstone XUIDs are returned unchanged, so they keep their meaning
+// in the exported data.
+func (x XUID) Pseudonymize(key []byte) XUID {
+	if IsEmpty(x) || x.IsTombstone() {
+		return x
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(x.uuid[:])
+	// The prefix is kept as is, as SetPrefix may have set one that NewWith
+	// would reject.
+	return XUID{uuid: hashUUID(mac.Sum(nil)), prefix: x.prefix}
+}
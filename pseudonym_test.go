@@ -0,0 +1,52 @@
+package xuid_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPseudonymize(t *testing.T) {
+	key := []byte("export-key")
+
+	t.Run("is deterministic and keeps the prefix", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+
+		p := x.Pseudonymize(key)
+
+		assert.Equal(t, p, x.Pseudonymize(key))
+		assert.Equal(t, "user", p.GetPrefix())
+		assert.NotEqual(t, x.GetUUID(), p.GetUUID())
+		assert.Equal(t, uuid.Version(8), p.GetUUID().Version())
+		assert.Equal(t, uuid.RFC4122, p.GetUUID().Variant())
+	})
+
+	t.Run("depends on the key", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+
+		assert.NotEqual(t, x.Pseudonymize(key), x.Pseudonymize([]byte("other-key")))
+	})
+
+	t.Run("ignores the prefix of the input", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+		y, _ := xuid.NewWith(x.GetUUID(), "member")
+
+		assert.Equal(t, x.Pseudonymize(key).GetUUID(), y.Pseudonymize(key).GetUUID())
+	})
+
+	t.Run("keeps prefixes set without validation", func(t *testing.T) {
+		long := strings.Repeat("a", xuid.MaxPrefixLen+1)
+		x := xuid.MustNewSortable("")
+		x.SetPrefix(long)
+
+		assert.Equal(t, long, x.Pseudonymize(key).GetPrefix())
+	})
+
+	t.Run("keeps nil and tombstone XUIDs", func(t *testing.T) {
+		assert.Equal(t, xuid.XUID{}, xuid.XUID{}.Pseudonymize(key))
+		assert.True(t, xuid.MustTombstone("user").Pseudonymize(key).IsTombstone())
+	})
+}
@@ -0,0 +1,117 @@
+package xuid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// base45Alphabet is the alphabet of RFC 9285, which is the character set
+// of the QR code alphanumeric mode.
+const base45Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// QRPayloadLen is the length of the payload part of QR payloads.
+const QRPayloadLen = 24
+
+// QRSeparator separates the prefix from the payload in QR payloads.
+const QRSeparator = ":"
+
+// QRPayload returns x encoded for the alphanumeric mode of QR codes, which
+// stores 5.5 bits per character but only supports digits, uppercase letters
+// and " $%*+-./:". The prefix is uppercased and followed by QRSeparator,
+// and the UUID is encoded in RFC 9285 Base45, such as
+// "TOTE:X80/D1$ LT3F4*NGSMS915AG". Prefixes with uppercase letters or
+// characters outside the alphanumeric set, which would not round-trip, fail
+// with ErrQRPrefix.
+func (x XUID) QRPayload() (string, error) {
+	invalid := func(r rune) bool { return !strings.ContainsRune(base45Alphabet, r) }
+	if strings.ToLower(x.prefix) != x.prefix || strings.IndexFunc(strings.ToUpper(x.prefix), invalid) >= 0 {
+		return "", fmt.Errorf("%w: %q", ErrQRPrefix, x.prefix)
+	}
+	b := make([]byte, 0, len(x.prefix)+len(QRSeparator)+QRPayloadLen)
+	if x.prefix != "" {
+		b = append(b, strings.ToUpper(x.prefix)...)
+		b = append(b, QRSeparator...)
+	}
+	for i := 0; i < len(x.uuid); i += 2 {
+		n := int(x.uuid[i])<<8 | int(x.uuid[i+1])
+		b = append(b, base45Alphabet[n%45], base45Alphabet[n/45%45], base45Alphabet[n/2025])
+	}
+	return string(b), nil
+}
+
+// ParseQRPayload parses a payload created by QRPayload, lowercasing the
+// prefix.
+func ParseQRPayload(s string) (XUID, error) {
+	if len(s) < QRPayloadLen {
+		return XUID{}, fmt.Errorf("%w: QR payload %q is too short", ErrParse, s)
+	}
+	prefix, payload := s[:len(s)-QRPayloadLen], s[len(s)-QRPayloadLen:]
+	if prefix != "" {
+		var ok bool
+		if prefix, ok = strings.CutSuffix(prefix, QRSeparator); !ok || prefix == "" {
+			return XUID{}, fmt.Errorf("%w: QR payload %q has no separator", ErrParse, s)
+		}
+	}
+	var id uuid.UUID
+	for i := 0; i < len(payload); i += 3 {
+		c, d, e := strings.IndexByte(base45Alphabet, payload[i]), strings.IndexByte(base45Alphabet, payload[i+1]), strings.IndexByte(base45Alphabet, payload[i+2])
+		n := c + d*45 + e*2025
+		if c < 0 || d < 0 || e < 0 || n > 0xffff {
+			return XUID{}, fmt.Errorf("%w: QR payload %q is not Base45", ErrParse, s)
+		}
+		id[i/3*2], id[i/3*2+1] = byte(n>>8), byte(n)
+	}
+	return NewWith(id, strings.ToLower(prefix))
+}
+
+// QRLevel is the error correction level of a QR code.
+type QRLevel int
+
+const (
+	QRLevelL QRLevel = iota // recovers 7% of data
+	QRLevelM                // recovers 15% of data
+	QRLevelQ                // recovers 25% of data
+	QRLevelH                // recovers 30% of data
+)
+
+// qrAlphanumericCapacity holds the alphanumeric mode capacities of QR
+// versions 1 to 10 per error correction level, from ISO/IEC 18004.
+var qrAlphanumericCapacity = [10][4]int{
+	{25, 20, 16, 10},
+	{47, 38, 29, 20},
+	{77, 61, 47, 35},
+	{114, 90, 67, 50},
+	{154, 122, 87, 64},
+	{195, 154, 108, 84},
+	{224, 178, 125, 93},
+	{279, 221, 157, 122},
+	{335, 262, 189, 143},
+	{395, 311, 221, 174},
+}
+
+// MaxQRVersion is the largest QR version known to QRCapacity.
+const MaxQRVersion = len(qrAlphanumericCapacity)
+
+// QRCapacity returns the number of alphanumeric mode characters a QR code
+// of version, from 1 to MaxQRVersion, holds at level, or 0 for other
+// versions and levels.
+func QRCapacity(version int, level QRLevel) int {
+	if version < 1 || version > MaxQRVersion || level < QRLevelL || level > QRLevelH {
+		return 0
+	}
+	return qrAlphanumericCapacity[version-1][level]
+}
+
+// QRVersion returns the smallest QR version holding payload, an
+// alphanumeric string such as one returned by QRPayload, at level, or 0 if
+// it does not fit in version MaxQRVersion.
+func QRVersion(payload string, level QRLevel) int {
+	for v := 1; v <= MaxQRVersion; v++ {
+		if len(payload) <= QRCapacity(v, level) {
+			return v
+		}
+	}
+	return 0
+}
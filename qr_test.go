@@ -0,0 +1,68 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRPayload(t *testing.T) {
+	u := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+
+	t.Run("encodes the UUID in Base45", func(t *testing.T) {
+		payload, err := xuid.Must(xuid.NewWith(u, "tote")).QRPayload()
+
+		require.NoError(t, err)
+		assert.Equal(t, "TOTE:X80/D1$ LT3F4*NGSMS915AG", payload)
+	})
+
+	t.Run("round-trips", func(t *testing.T) {
+		for _, prefix := range []string{"", "tote", "bin-2", "a:b"} {
+			id := xuid.Must(xuid.NewWith(u, prefix))
+
+			payload, err := id.QRPayload()
+			require.NoError(t, err, prefix)
+			parsed, err := xuid.ParseQRPayload(payload)
+
+			require.NoError(t, err, prefix)
+			assert.Equal(t, id, parsed, prefix)
+		}
+	})
+
+	t.Run("rejects prefixes that do not round-trip", func(t *testing.T) {
+		for _, prefix := range []string{"Tote", "tote_bin", "café"} {
+			_, err := xuid.Must(xuid.NewWith(u, prefix)).QRPayload()
+
+			assert.ErrorIs(t, err, xuid.ErrQRPrefix, prefix)
+		}
+	})
+}
+
+func TestParseQRPayload(t *testing.T) {
+	for _, s := range []string{
+		"X80/D1$ LT3F4*NGSMS915A",
+		"TOTEX80/D1$ LT3F4*NGSMS915AG",
+		":X80/D1$ LT3F4*NGSMS915AG",
+		"TOTE:X80/D1$ LT3F4*NGSMS915Ag",
+		"TOTE:X80/D1$ LT3F4*NGSMS9:::",
+	} {
+		_, err := xuid.ParseQRPayload(s)
+
+		assert.ErrorIs(t, err, xuid.ErrParse, s)
+	}
+}
+
+func TestQRCapacity(t *testing.T) {
+	assert.Equal(t, 25, xuid.QRCapacity(1, xuid.QRLevelL))
+	assert.Equal(t, 174, xuid.QRCapacity(10, xuid.QRLevelH))
+	assert.Equal(t, 0, xuid.QRCapacity(0, xuid.QRLevelL))
+	assert.Equal(t, 0, xuid.QRCapacity(xuid.MaxQRVersion+1, xuid.QRLevelL))
+
+	payload, _ := xuid.Must(xuid.NewWith(uuid.Nil, "tote")).QRPayload()
+	assert.Equal(t, 2, xuid.QRVersion(payload, xuid.QRLevelM))
+	assert.Equal(t, 1, xuid.QRVersion(payload[5:], xuid.QRLevelL))
+	assert.Equal(t, 0, xuid.QRVersion(string(make([]byte, 400)), xuid.QRLevelL))
+}
@@ -0,0 +1,66 @@
+package xuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Range is the set of XUIDs whose UUID is between Start and End, both
+// included, in the order defined by Compare. Prefixes are ignored. For
+// sortable XUIDs, ranges built with TimeRange select creation times.
+type Range struct {
+	Start uuid.UUID
+	End   uuid.UUID
+}
+
+// NewRange returns the range from the UUID of start to the UUID of end.
+func NewRange(start, end XUID) Range {
+	return Range{Start: start.uuid, End: end.uuid}
+}
+
+// maxMillis bounds the 48-bit timestamps of sortable XUIDs.
+const maxMillis = 1 << 48
+
+// TimeRange returns the range of sortable XUIDs created in [from, to), with
+// millisecond precision. Times outside the timestamps of sortable XUIDs,
+// from the Unix epoch to 2^48 milliseconds after it, are clamped to them,
+// so the range is empty when to is at or before the epoch.
+func TimeRange(from, to time.Time) Range {
+	var r Range
+	start, end := clampMillis(from.UnixMilli()), clampMillis(to.UnixMilli())
+	if start >= end {
+		r.Start = uuid.Max
+		return r
+	}
+	putMillis(&r.Start, start)
+	r.Start[6], r.Start[8] = 0x70, 0x80
+	putMillis(&r.End, end-1)
+	for i := 6; i < 16; i++ {
+		r.End[i] = 0xff
+	}
+	r.End[6], r.End[8] = 0x7f, 0xbf
+	return r
+}
+
+func clampMillis(ms int64) int64 {
+	return min(max(ms, 0), maxMillis)
+}
+
+func putMillis(id *uuid.UUID, ms int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(ms))
+	copy(id[:6], b[2:])
+}
+
+// Contains reports whether x is in r.
+func (r Range) Contains(x XUID) bool {
+	return bytes.Compare(r.Start[:], x.uuid[:]) <= 0 && bytes.Compare(x.uuid[:], r.End[:]) <= 0
+}
+
+// Empty reports whether r contains no XUID, because End is before Start.
+func (r Range) Empty() bool {
+	return bytes.Compare(r.Start[:], r.End[:]) > 0
+}
@@ -0,0 +1,69 @@
+package xuid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeRange(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	r := xuid.TimeRange(from, to)
+
+	assert.True(t, r.Contains(xuid.Must(xuid.NewSortableAt("evt", from))))
+	assert.True(t, r.Contains(xuid.Must(xuid.NewSortableAt("evt", to.Add(-time.Millisecond)))))
+	assert.False(t, r.Contains(xuid.Must(xuid.NewSortableAt("evt", to))))
+	assert.False(t, r.Contains(xuid.Must(xuid.NewSortableAt("evt", from.Add(-time.Millisecond)))))
+	assert.False(t, r.Empty())
+	assert.True(t, xuid.TimeRange(to, from).Empty())
+
+	t.Run("is empty up to the epoch", func(t *testing.T) {
+		epoch := time.Unix(0, 0)
+		first := xuid.Must(xuid.NewSortableAt("evt", epoch))
+
+		for _, r := range []xuid.Range{
+			xuid.TimeRange(epoch, epoch),
+			xuid.TimeRange(epoch.Add(-time.Hour), epoch),
+			xuid.TimeRange(epoch.Add(-time.Hour), epoch.Add(-time.Minute)),
+		} {
+			assert.True(t, r.Empty())
+			assert.False(t, r.Contains(first))
+		}
+	})
+
+	t.Run("clamps times before the epoch", func(t *testing.T) {
+		epoch := time.Unix(0, 0)
+
+		r := xuid.TimeRange(epoch.Add(-time.Hour), epoch.Add(time.Millisecond))
+
+		assert.True(t, r.Contains(xuid.Must(xuid.NewSortableAt("evt", epoch))))
+		assert.False(t, r.Contains(xuid.Must(xuid.NewSortableAt("evt", epoch.Add(time.Millisecond)))))
+	})
+
+	t.Run("clamps times after the last timestamp", func(t *testing.T) {
+		last := xuid.Must(xuid.NewWith(uuid.MustParse("ffffffff-ffff-7fff-bfff-ffffffffffff"), "evt"))
+		far := time.UnixMilli(1 << 49)
+
+		r := xuid.TimeRange(from, far)
+
+		assert.True(t, r.Contains(last))
+		assert.True(t, r.Contains(xuid.Must(xuid.NewSortableAt("evt", from))))
+		assert.True(t, xuid.TimeRange(far, far.Add(time.Hour)).Empty())
+	})
+}
+
+func TestNewRange(t *testing.T) {
+	lo := xuid.Must(xuid.NewWith(uuid.MustParse("10000000-0000-4000-8000-000000000000"), "a"))
+	mid := xuid.Must(xuid.NewWith(uuid.MustParse("20000000-0000-4000-8000-000000000000"), "b"))
+	hi := xuid.Must(xuid.NewWith(uuid.MustParse("30000000-0000-4000-8000-000000000000"), "c"))
+
+	r := xuid.NewRange(lo, mid)
+
+	assert.True(t, r.Contains(lo))
+	assert.True(t, r.Contains(mid))
+	assert.False(t, r.Contains(hi))
+}
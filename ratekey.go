@@ -0,0 +1,23 @@
+package xuid
+
+import (
+	"strconv"
+	"time"
+)
+
+// RateKey returns a rate limiter key for x and the current window of length
+// window, of the form "prefix_payload:window:n", where n counts windows
+// since the Unix epoch, such as "user_8M7Qq2vR3kGbF9wN5pL2xA:1m0s:28433871".
+// Keys change when a new window starts, so counters stored under them
+// reset without being deleted. RateKey panics if window is not positive.
+func (x XUID) RateKey(window time.Duration) string {
+	return x.RateKeyAt(window, time.Now())
+}
+
+// RateKeyAt is like RateKey but uses the window containing t.
+func (x XUID) RateKeyAt(window time.Duration, t time.Time) string {
+	if window <= 0 {
+		panic("xuid: non-positive window for RateKey")
+	}
+	return x.String() + ":" + window.String() + ":" + strconv.FormatInt(t.UnixNano()/int64(window), 10)
+}
@@ -0,0 +1,36 @@
+package xuid_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateKey(t *testing.T) {
+	x, _ := xuid.NewWith(uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057"), "user")
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("formats XUID, window and window number", func(t *testing.T) {
+		assert.Equal(t, x.String()+":1m0s:28401840", x.RateKeyAt(time.Minute, start))
+	})
+
+	t.Run("is stable within a window", func(t *testing.T) {
+		assert.Equal(t, x.RateKeyAt(time.Minute, start), x.RateKeyAt(time.Minute, start.Add(59*time.Second)))
+		assert.NotEqual(t, x.RateKeyAt(time.Minute, start), x.RateKeyAt(time.Minute, start.Add(time.Minute)))
+	})
+
+	t.Run("differs between window lengths", func(t *testing.T) {
+		assert.NotEqual(t, x.RateKeyAt(time.Minute, start), x.RateKeyAt(time.Hour, start))
+	})
+
+	t.Run("uses the current time", func(t *testing.T) {
+		assert.Equal(t, x.RateKeyAt(time.Hour, time.Now()), x.RateKey(time.Hour))
+	})
+
+	t.Run("panics on non-positive window", func(t *testing.T) {
+		assert.Panics(t, func() { x.RateKey(0) })
+	})
+}
@@ -0,0 +1,54 @@
+package xuid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RedisKey returns a key namespaced by the prefix, such as
+// "user:{8M7Qq2vR3kGbF9wN5pL2xA}", followed by parts separated by colons.
+// The payload is wrapped in braces so that Redis Cluster stores every key
+// of a XUID, such as "user:{...}" and "user:{...}:sessions", in the same
+// hash slot. Prefixes must not contain braces, which would change the
+// hash slot and prevent ParseRedisKey from finding the payload.
+func (x XUID) RedisKey(parts ...string) string {
+	var b strings.Builder
+	if x.prefix != "" {
+		b.WriteString(x.prefix)
+		b.WriteByte(':')
+	}
+	b.WriteByte('{')
+	b.WriteString(x.payload())
+	b.WriteByte('}')
+	for _, p := range parts {
+		b.WriteByte(':')
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// ParseRedisKey returns the XUID of a key created by RedisKey, ignoring
+// any parts after the payload.
+func ParseRedisKey(key string) (XUID, error) {
+	start := strings.Index(key, "{")
+	end := strings.Index(key[start+1:], "}")
+	if start < 0 || end < 0 {
+		return XUID{}, fmt.Errorf("%w: %q is not a XUID Redis key", ErrParse, key)
+	}
+	prefix := key[:start]
+	if prefix != "" {
+		var ok bool
+		if prefix, ok = strings.CutSuffix(prefix, ":"); !ok {
+			return XUID{}, fmt.Errorf("%w: %q is not a XUID Redis key", ErrParse, key)
+		}
+	}
+	payload := key[start+1 : start+1+end]
+	p, id, err := parse(payload)
+	if err != nil {
+		return XUID{}, err
+	}
+	if p != "" {
+		return XUID{}, fmt.Errorf("%w: %q is not a XUID Redis key", ErrParse, key)
+	}
+	return NewWith(id, prefix)
+}
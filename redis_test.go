@@ -0,0 +1,62 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisKey(t *testing.T) {
+	id := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	x, err := xuid.NewWith(id, "user")
+	require.NoError(t, err)
+	payload := xuid.Must(xuid.NewWith(id, "")).String()
+
+	t.Run("namespaces key by prefix", func(t *testing.T) {
+		assert.Equal(t, "user:{"+payload+"}", x.RedisKey())
+	})
+
+	t.Run("appends parts", func(t *testing.T) {
+		assert.Equal(t, "user:{"+payload+"}:sessions:active", x.RedisKey("sessions", "active"))
+	})
+
+	t.Run("omits namespace without prefix", func(t *testing.T) {
+		assert.Equal(t, "{"+payload+"}", xuid.Must(xuid.NewWith(id, "")).RedisKey())
+	})
+
+	t.Run("ParseRedisKey round-trips", func(t *testing.T) {
+		for _, key := range []string{x.RedisKey(), x.RedisKey("sessions")} {
+			parsed, err := xuid.ParseRedisKey(key)
+
+			require.NoError(t, err, key)
+			assert.True(t, x.Equal(parsed), key)
+		}
+	})
+
+	t.Run("ParseRedisKey round-trips prefixes with separators", func(t *testing.T) {
+		y := xuid.Must(xuid.NewWith(id, "user_profile"))
+
+		parsed, err := xuid.ParseRedisKey(y.RedisKey())
+
+		require.NoError(t, err)
+		assert.True(t, y.Equal(parsed))
+	})
+
+	t.Run("ParseRedisKey rejects other keys", func(t *testing.T) {
+		for _, key := range []string{
+			"",
+			"user:" + payload,
+			"user:{" + payload,
+			"user{" + payload + "}",
+			"user:{user_" + payload + "}",
+			"user:{0OIl}",
+		} {
+			_, err := xuid.ParseRedisKey(key)
+
+			assert.ErrorIs(t, err, xuid.ErrParse, key)
+		}
+	})
+}
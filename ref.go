@@ -0,0 +1,141 @@
+package xuid
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Ref is a XUID of any registered entity, for columns and fields pointing
+// at several entity types, such as the subject of a comment or an
+// attachment. The entity is resolved from the prefix, so Refs are stored in
+// their string form, in text columns, and the prefix must be registered.
+// The zero value is a nil reference, encoded as JSON null and SQL NULL.
+type Ref struct {
+	id     XUID
+	entity string
+}
+
+// NewRef returns a reference to x. It fails with ErrUnknownEntity if the
+// prefix of x is not registered.
+func NewRef(x XUID) (Ref, error) {
+	entity, ok := TypeOf(x)
+	if !ok {
+		return Ref{}, fmt.Errorf("%w: prefix %q", ErrUnknownEntity, x.prefix)
+	}
+	return Ref{id: x, entity: entity}, nil
+}
+
+// ParseRef parses a reference from the string form of a XUID whose prefix
+// is registered.
+func ParseRef(s string) (Ref, error) {
+	x, err := Parse(s)
+	if err != nil {
+		return Ref{}, err
+	}
+	return NewRef(x)
+}
+
+// ID returns the referenced XUID.
+func (r Ref) ID() XUID {
+	return r.id
+}
+
+// Entity returns the entity of the referenced XUID, or "" for a nil
+// reference.
+func (r Ref) Entity() string {
+	return r.entity
+}
+
+// IsNil reports whether r is a nil reference.
+func (r Ref) IsNil() bool {
+	return r == Ref{}
+}
+
+// Resolve calls f with the entity and XUID of r, so callers can dispatch
+// on the entity, such as to load the referenced row from its table. It
+// fails with ErrMissingXUID, without calling f, for a nil reference.
+func (r Ref) Resolve(f func(entity string, id XUID) error) error {
+	if r.IsNil() {
+		return ErrMissingXUID
+	}
+	return f(r.entity, r.id)
+}
+
+func (r Ref) String() string {
+	if r.IsNil() {
+		return ""
+	}
+	return r.id.String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r Ref) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// Empty text is read as a nil reference.
+func (r *Ref) UnmarshalText(data []byte) error {
+	return r.parse(string(data))
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r Ref) MarshalJSON() ([]byte, error) {
+	if r.IsNil() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (r *Ref) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*r = Ref{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return r.parse(s)
+}
+
+// Value implements the driver.Valuer interface. Unlike XUID, it stores the
+// string form, which keeps the prefix.
+func (r Ref) Value() (driver.Value, error) {
+	if r.IsNil() {
+		return nil, nil
+	}
+	return r.String(), nil
+}
+
+// Scan implements the sql.Scanner interface. NULL scans to a nil reference.
+func (r *Ref) Scan(value interface{}) error {
+	switch d := value.(type) {
+	case nil:
+		*r = Ref{}
+		return nil
+	case string:
+		return r.parse(d)
+	case []byte:
+		return r.parse(string(d))
+	}
+	return errors.New("unsupported type to scan as sql value")
+}
+
+// parse parses s into r, reading "" as a nil reference. r is left unchanged
+// when parsing fails.
+func (r *Ref) parse(s string) error {
+	if s == "" {
+		*r = Ref{}
+		return nil
+	}
+	ref, err := ParseRef(s)
+	if err != nil {
+		return err
+	}
+	*r = ref
+	return nil
+}
@@ -0,0 +1,95 @@
+package xuid_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRef(t *testing.T) {
+	xuid.MustRegister("RefPost", "refpost")
+	xuid.MustRegister("RefPhoto", "refphoto")
+	post := xuid.MustNewSortable("refpost")
+	photo := xuid.MustNewSortable("refphoto")
+
+	t.Run("resolves the entity from the prefix", func(t *testing.T) {
+		ref, err := xuid.NewRef(photo)
+		require.NoError(t, err)
+
+		var entity string
+		var id xuid.XUID
+		err = ref.Resolve(func(e string, x xuid.XUID) error {
+			entity, id = e, x
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "RefPhoto", entity)
+		assert.Equal(t, photo, id)
+		assert.Equal(t, "RefPhoto", ref.Entity())
+		assert.Equal(t, photo, ref.ID())
+	})
+
+	t.Run("returns errors of the resolver", func(t *testing.T) {
+		ref, _ := xuid.NewRef(post)
+		boom := errors.New("boom")
+
+		assert.ErrorIs(t, ref.Resolve(func(string, xuid.XUID) error { return boom }), boom)
+	})
+
+	t.Run("rejects unregistered prefixes", func(t *testing.T) {
+		_, err := xuid.ParseRef(xuid.MustNewSortable("refunknown").String())
+
+		assert.ErrorIs(t, err, xuid.ErrUnknownEntity)
+	})
+
+	t.Run("does not resolve nil references", func(t *testing.T) {
+		var ref xuid.Ref
+
+		err := ref.Resolve(func(string, xuid.XUID) error {
+			t.Fatal("resolver called")
+			return nil
+		})
+
+		assert.True(t, ref.IsNil())
+		assert.ErrorIs(t, err, xuid.ErrMissingXUID)
+	})
+
+	t.Run("round-trips JSON", func(t *testing.T) {
+		type comment struct {
+			Subject xuid.Ref `json:"subject"`
+			Parent  xuid.Ref `json:"parent"`
+		}
+		ref, _ := xuid.NewRef(post)
+
+		data, err := json.Marshal(comment{Subject: ref})
+		require.NoError(t, err)
+		var got comment
+		require.NoError(t, json.Unmarshal(data, &got))
+
+		assert.JSONEq(t, `{"subject":"`+post.String()+`","parent":null}`, string(data))
+		assert.Equal(t, comment{Subject: ref}, got)
+	})
+
+	t.Run("stores the string form in SQL", func(t *testing.T) {
+		ref, _ := xuid.NewRef(post)
+
+		v, err := ref.Value()
+		require.NoError(t, err)
+		var got xuid.Ref
+		require.NoError(t, got.Scan([]byte(v.(string))))
+
+		assert.Equal(t, post.String(), v)
+		assert.Equal(t, ref, got)
+
+		require.NoError(t, got.Scan(nil))
+		assert.True(t, got.IsNil())
+		v, err = got.Value()
+		require.NoError(t, err)
+		assert.Nil(t, v)
+	})
+}
@@ -0,0 +1,71 @@
+package xuid
+
+import (
+	"fmt"
+	"sync"
+)
+
+// The registry maps entity names, such as "User", to the prefixes of their
+// XUIDs, such as "user". It is shared by every package using this one, so
+// registrations usually happen in init functions or at startup.
+var registry = struct {
+	sync.RWMutex
+	prefixes map[string]string
+	entities map[string]string
+}{
+	prefixes: map[string]string{},
+	entities: map[string]string{},
+}
+
+// Register associates entity with prefix in the registry.
+// Registering the same pair again is a no-op; registering an entity or a
+// prefix that is already associated with something else fails with
+// ErrRegistryConflict.
+func Register(entity, prefix string) error {
+	registry.Lock()
+	defer registry.Unlock()
+
+	p, entityOK := registry.prefixes[entity]
+	e, prefixOK := registry.entities[prefix]
+	if entityOK && prefixOK && p == prefix && e == entity {
+		return nil
+	}
+	if entityOK {
+		return fmt.Errorf("%w: entity %q has prefix %q", ErrRegistryConflict, entity, p)
+	}
+	if prefixOK {
+		return fmt.Errorf("%w: prefix %q belongs to entity %q", ErrRegistryConflict, prefix, e)
+	}
+	registry.prefixes[entity] = prefix
+	registry.entities[prefix] = entity
+	InternPrefixes(prefix)
+	return nil
+}
+
+func MustRegister(entity, prefix string) {
+	if err := Register(entity, prefix); err != nil {
+		panic(err)
+	}
+}
+
+// PrefixFor returns the prefix registered for entity.
+func PrefixFor(entity string) (string, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	p, ok := registry.prefixes[entity]
+	return p, ok
+}
+
+// EntityFor returns the entity registered for prefix.
+func EntityFor(prefix string) (string, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	e, ok := registry.entities[prefix]
+	return e, ok
+}
+
+// TypeOf returns the entity registered for the prefix of x, so generic code
+// such as audit logs or permission checks, can describe XUIDs of any type.
+func TypeOf(x XUID) (string, bool) {
+	return EntityFor(x.prefix)
+}
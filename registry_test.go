@@ -0,0 +1,63 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("registers and looks up entities", func(t *testing.T) {
+		require.NoError(t, xuid.Register("RegistryUser", "reguser"))
+
+		prefix, ok := xuid.PrefixFor("RegistryUser")
+		assert.True(t, ok)
+		assert.Equal(t, "reguser", prefix)
+
+		entity, ok := xuid.EntityFor("reguser")
+		assert.True(t, ok)
+		assert.Equal(t, "RegistryUser", entity)
+	})
+
+	t.Run("accepts identical registration", func(t *testing.T) {
+		require.NoError(t, xuid.Register("RegistryOrder", "regorder"))
+
+		assert.NoError(t, xuid.Register("RegistryOrder", "regorder"))
+	})
+
+	t.Run("rejects conflicting registrations", func(t *testing.T) {
+		require.NoError(t, xuid.Register("RegistryFile", "regfile"))
+
+		assert.ErrorIs(t, xuid.Register("RegistryFile", "other"), xuid.ErrRegistryConflict)
+		assert.ErrorIs(t, xuid.Register("Other", "regfile"), xuid.ErrRegistryConflict)
+	})
+
+	t.Run("reports unknown entities and prefixes", func(t *testing.T) {
+		_, ok := xuid.PrefixFor("Unregistered")
+		assert.False(t, ok)
+
+		_, ok = xuid.EntityFor("unregistered")
+		assert.False(t, ok)
+	})
+
+	t.Run("resolves the entity of XUIDs", func(t *testing.T) {
+		require.NoError(t, xuid.Register("RegistryInvoice", "reginvoice"))
+
+		entity, ok := xuid.TypeOf(xuid.MustNewSortable("reginvoice"))
+		assert.True(t, ok)
+		assert.Equal(t, "RegistryInvoice", entity)
+
+		_, ok = xuid.TypeOf(xuid.MustNewSortable("unregistered"))
+		assert.False(t, ok)
+	})
+
+	t.Run("MustRegister panics on conflict", func(t *testing.T) {
+		xuid.MustRegister("RegistryTeam", "regteam")
+
+		assert.Panics(t, func() {
+			xuid.MustRegister("RegistryTeam", "other")
+		})
+	})
+}
@@ -0,0 +1,81 @@
+package xuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// RenamePrefix returns x with prefix newPrefix if its prefix is oldPrefix,
+// and x unchanged otherwise. The UUID is kept, so renamed XUIDs still
+// match stored UUIDs. newPrefix is validated as in NewWith.
+func (x XUID) RenamePrefix(oldPrefix, newPrefix string) (XUID, error) {
+	if _, err := NewWith(uuid.Nil, newPrefix); err != nil {
+		return XUID{}, err
+	}
+	if x.prefix == oldPrefix {
+		x.prefix = newPrefix
+	}
+	return x, nil
+}
+
+// RenamePrefixes renames oldPrefix to newPrefix in ids, in place, as
+// RenamePrefix does, and returns the number of XUIDs renamed.
+func RenamePrefixes(ids []XUID, oldPrefix, newPrefix string) (int, error) {
+	if _, err := NewWith(uuid.Nil, newPrefix); err != nil {
+		return 0, err
+	}
+	n := 0
+	for i := range ids {
+		if ids[i].prefix == oldPrefix {
+			ids[i].prefix = newPrefix
+			n++
+		}
+	}
+	return n, nil
+}
+
+// RenamePrefixJSON renames oldPrefix to newPrefix in every string of the
+// JSON document data, object keys included, that is a XUID, and returns the
+// updated document and the number of XUIDs renamed. The rest of the
+// document, including its formatting, is kept byte for byte. Strings with
+// escape sequences are left unchanged.
+func RenamePrefixJSON(data []byte, oldPrefix, newPrefix string) ([]byte, int, error) {
+	if _, err := NewWith(uuid.Nil, newPrefix); err != nil {
+		return nil, 0, err
+	}
+	if !json.Valid(data) {
+		return nil, 0, errors.New("invalid JSON document")
+	}
+	var res bytes.Buffer
+	res.Grow(len(data))
+	n := 0
+	for {
+		start := bytes.IndexByte(data, '"')
+		if start < 0 {
+			res.Write(data)
+			return res.Bytes(), n, nil
+		}
+		end, escaped := start+1, false
+		for data[end] != '"' {
+			if data[end] == '\\' {
+				escaped = true
+				end++
+			}
+			end++
+		}
+		res.Write(data[:start+1])
+		s := data[start+1 : end]
+		if x, err := Parse(string(s)); !escaped && err == nil && x.prefix == oldPrefix {
+			x.prefix = newPrefix
+			s = x.appendString(nil)
+			n++
+		}
+		res.Write(s)
+		data = data[end:]
+		res.WriteByte('"')
+		data = data[1:]
+	}
+}
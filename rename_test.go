@@ -0,0 +1,78 @@
+package xuid_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenamePrefix(t *testing.T) {
+	t.Run("renames the matching prefix and keeps the UUID", func(t *testing.T) {
+		x := xuid.MustNewSortable("proj")
+
+		renamed, err := x.RenamePrefix("proj", "workspace")
+
+		require.NoError(t, err)
+		assert.Equal(t, "workspace", renamed.GetPrefix())
+		assert.Equal(t, x.GetUUID(), renamed.GetUUID())
+	})
+
+	t.Run("keeps other prefixes", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+
+		renamed, err := x.RenamePrefix("proj", "workspace")
+
+		require.NoError(t, err)
+		assert.Equal(t, x, renamed)
+	})
+
+	t.Run("validates the new prefix", func(t *testing.T) {
+		_, err := xuid.MustNewSortable("proj").RenamePrefix("proj", strings.Repeat("a", xuid.MaxPrefixLen+1))
+
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+	})
+}
+
+func TestRenamePrefixes(t *testing.T) {
+	ids := []xuid.XUID{xuid.MustNewSortable("proj"), xuid.MustNewSortable("user"), xuid.MustNewSortable("proj")}
+	uuids := []string{ids[0].GetUUID().String(), ids[1].GetUUID().String(), ids[2].GetUUID().String()}
+
+	n, err := xuid.RenamePrefixes(ids, "proj", "workspace")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"workspace", "user", "workspace"}, []string{ids[0].GetPrefix(), ids[1].GetPrefix(), ids[2].GetPrefix()})
+	for i, id := range ids {
+		assert.Equal(t, uuids[i], id.GetUUID().String())
+	}
+}
+
+func TestRenamePrefixJSON(t *testing.T) {
+	proj := xuid.MustNewSortable("proj")
+	user := xuid.MustNewSortable("user")
+	workspace := proj
+	workspace.SetPrefix("workspace")
+
+	t.Run("renames values and keys and keeps formatting", func(t *testing.T) {
+		doc := `{
+  "id": "` + proj.String() + `",
+  "owner": "` + user.String() + `",
+  "members": {"` + proj.String() + `": [1, "proj", "x\"y"]}
+}`
+
+		res, n, err := xuid.RenamePrefixJSON([]byte(doc), "proj", "workspace")
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, strings.ReplaceAll(doc, proj.String(), workspace.String()), string(res))
+	})
+
+	t.Run("rejects invalid documents", func(t *testing.T) {
+		_, _, err := xuid.RenamePrefixJSON([]byte(`{"id": `), "proj", "workspace")
+
+		assert.Error(t, err)
+	})
+}
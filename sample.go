@@ -0,0 +1,93 @@
+package xuid
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+)
+
+// Sample creates n sortable XUIDs with the given prefix whose timestamps are
+// uniformly distributed in [from, to). As timestamps have millisecond
+// precision, the range must contain a whole millisecond. The XUIDs are
+// returned in random order. It is meant for building synthetic datasets,
+// such as load test fixtures, that mimic the key distribution of
+// production data.
+func Sample(prefix string, n int, from, to time.Time) ([]XUID, error) {
+	if n < 0 {
+		return nil, ErrNegativeCount
+	}
+	start := ceilMillis(from)
+	span := ceilMillis(to) - start
+	if span <= 0 {
+		return nil, errors.New("sample range is empty")
+	}
+	res := make([]XUID, n)
+	for i := range res {
+		xid, err := NewSortableAt(prefix, time.UnixMilli(start+rand.Int64N(span)))
+		if err != nil {
+			return nil, err
+		}
+		res[i] = xid
+	}
+	return res, nil
+}
+
+// ceilMillis returns the Unix time of t in milliseconds, rounded up.
+func ceilMillis(t time.Time) int64 {
+	ms := t.UnixMilli()
+	if time.UnixMilli(ms).Before(t) {
+		ms++
+	}
+	return ms
+}
+
+// SampleWeighted is like Sample but spreads the n XUIDs over several
+// prefixes in proportion to their weights. Each prefix receives its share
+// rounded down, and the remainder is spread in order of decreasing weight.
+func SampleWeighted(weights map[string]float64, n int, from, to time.Time) ([]XUID, error) {
+	if n < 0 {
+		return nil, ErrNegativeCount
+	}
+	prefixes := make([]string, 0, len(weights))
+	total := 0.0
+	for p, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, errors.New("sample weights must be finite and not negative")
+		}
+		prefixes = append(prefixes, p)
+		total += w
+	}
+	if total == 0 {
+		return nil, errors.New("sample weights must not all be zero")
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		if weights[prefixes[i]] != weights[prefixes[j]] {
+			return weights[prefixes[i]] > weights[prefixes[j]]
+		}
+		return prefixes[i] < prefixes[j]
+	})
+
+	counts := make([]int, len(prefixes))
+	assigned := 0
+	for i, p := range prefixes {
+		counts[i] = int(float64(n) * weights[p] / total)
+		assigned += counts[i]
+	}
+	for i := 0; assigned < n; i = (i + 1) % len(prefixes) {
+		counts[i]++
+		assigned++
+	}
+
+	res := make([]XUID, 0, n)
+	for i, p := range prefixes {
+		ids, err := Sample(p, counts[i], from, to)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ids...)
+	}
+	rand.Shuffle(len(res), func(i, j int) { res[i], res[j] = res[j], res[i] })
+	return res, nil
+}
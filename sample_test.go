@@ -0,0 +1,132 @@
+package xuid_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSortableAt(t *testing.T) {
+	t.Run("embeds given timestamp", func(t *testing.T) {
+		at := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+
+		id, err := xuid.NewSortableAt("user", at)
+
+		require.NoError(t, err)
+		assert.True(t, id.IsSortable())
+		assert.Equal(t, "user", id.GetPrefix())
+		info, err := xuid.InspectString(id.String())
+		require.NoError(t, err)
+		assert.True(t, at.Equal(info.Time))
+	})
+
+	t.Run("sorts by timestamp", func(t *testing.T) {
+		at := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+		id1, _ := xuid.NewSortableAt("user", at)
+		id2, _ := xuid.NewSortableAt("user", at.Add(time.Millisecond))
+
+		assert.Equal(t, -1, xuid.Compare(id1, id2))
+	})
+}
+
+func TestSample(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	t.Run("creates IDs within range", func(t *testing.T) {
+		ids, err := xuid.Sample("user", 500, from, to)
+
+		require.NoError(t, err)
+		require.Len(t, ids, 500)
+		for _, id := range ids {
+			info, err := xuid.InspectString(id.String())
+			require.NoError(t, err)
+			assert.Equal(t, "user", info.Prefix)
+			assert.False(t, info.Time.Before(from))
+			assert.True(t, info.Time.Before(to))
+		}
+	})
+
+	t.Run("spreads IDs over the range", func(t *testing.T) {
+		ids, err := xuid.Sample("user", 1000, from, to)
+		require.NoError(t, err)
+
+		firstHalf := 0
+		for _, id := range ids {
+			info, _ := xuid.InspectString(id.String())
+			if info.Time.Before(from.Add(12 * time.Hour)) {
+				firstHalf++
+			}
+		}
+		assert.InDelta(t, 500, firstHalf, 100)
+	})
+
+	t.Run("rounds sub-millisecond bounds into the range", func(t *testing.T) {
+		from := from.Add(500 * time.Microsecond)
+
+		ids, err := xuid.Sample("user", 100, from, from.Add(time.Millisecond))
+
+		require.NoError(t, err)
+		for _, id := range ids {
+			ts, err := id.Time()
+			require.NoError(t, err)
+			assert.False(t, ts.Before(from))
+		}
+	})
+
+	t.Run("rejects empty range", func(t *testing.T) {
+		_, err := xuid.Sample("user", 1, to, from)
+		assert.Error(t, err)
+
+		_, err = xuid.Sample("user", 1, from.Add(time.Microsecond), from.Add(time.Millisecond/2))
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects negative counts", func(t *testing.T) {
+		_, err := xuid.Sample("user", -1, from, to)
+
+		assert.ErrorIs(t, err, xuid.ErrNegativeCount)
+	})
+}
+
+func TestSampleWeighted(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	t.Run("spreads IDs by weight", func(t *testing.T) {
+		ids, err := xuid.SampleWeighted(map[string]float64{"user": 3, "order": 1}, 101, from, to)
+
+		require.NoError(t, err)
+		require.Len(t, ids, 101)
+		counts := map[string]int{}
+		for _, id := range ids {
+			counts[id.GetPrefix()]++
+		}
+		assert.Equal(t, 76, counts["user"])
+		assert.Equal(t, 25, counts["order"])
+	})
+
+	t.Run("rejects invalid weights", func(t *testing.T) {
+		_, err := xuid.SampleWeighted(map[string]float64{"user": 0}, 1, from, to)
+		assert.Error(t, err)
+
+		_, err = xuid.SampleWeighted(map[string]float64{"user": -1, "order": 2}, 1, from, to)
+		assert.Error(t, err)
+
+		_, err = xuid.SampleWeighted(map[string]float64{"user": math.NaN(), "order": 2}, 1, from, to)
+		assert.Error(t, err)
+
+		_, err = xuid.SampleWeighted(map[string]float64{"user": math.Inf(1)}, 1, from, to)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects negative counts", func(t *testing.T) {
+		_, err := xuid.SampleWeighted(map[string]float64{"user": 1}, -1, from, to)
+
+		assert.ErrorIs(t, err, xuid.ErrNegativeCount)
+	})
+}
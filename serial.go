@@ -0,0 +1,25 @@
+package xuid
+
+import (
+	"math/big"
+
+	"github.com/google/uuid"
+)
+
+// SerialNumber returns the UUID as a positive integer suitable for X.509
+// certificate serial numbers, which RFC 5280 limits to 20 octets.
+// The nil UUID yields zero, which is not a valid serial number.
+func (x XUID) SerialNumber() *big.Int {
+	return new(big.Int).SetBytes(x.uuid[:])
+}
+
+// FromSerialNumber is the inverse of SerialNumber.
+// The prefix is not part of the serial number and must be passed in.
+func FromSerialNumber(n *big.Int, prefix string) (XUID, error) {
+	if n == nil || n.Sign() < 0 || n.BitLen() > 128 {
+		return XUID{}, ErrInvalidSerial
+	}
+	var id uuid.UUID
+	n.FillBytes(id[:])
+	return NewWith(id, prefix)
+}
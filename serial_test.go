@@ -0,0 +1,93 @@
+package xuid_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSerialNumber(t *testing.T) {
+	t.Run("returns positive integer of the UUID bytes", func(t *testing.T) {
+		id := xuid.MustNewSortable("csr")
+		u := id.GetUUID()
+
+		n := id.SerialNumber()
+
+		assert.Equal(t, 1, n.Sign())
+		assert.Equal(t, new(big.Int).SetBytes(u[:]), n)
+		assert.LessOrEqual(t, len(n.Bytes()), 20)
+	})
+
+	t.Run("round trips", func(t *testing.T) {
+		id := xuid.MustNewSortable("csr")
+
+		parsed, err := xuid.FromSerialNumber(id.SerialNumber(), "csr")
+
+		require.NoError(t, err)
+		assert.True(t, id.Equal(parsed))
+	})
+
+	t.Run("round trips UUIDs with leading zero bytes", func(t *testing.T) {
+		u := uuid.New()
+		u[0], u[1] = 0, 0
+		id, _ := xuid.NewWith(u, "")
+
+		parsed, err := xuid.FromSerialNumber(id.SerialNumber(), "")
+
+		require.NoError(t, err)
+		assert.Equal(t, u, parsed.GetUUID())
+	})
+
+	t.Run("round trips through ASN.1", func(t *testing.T) {
+		id := xuid.MustNewRandom("csr")
+
+		der, err := asn1.Marshal(id.SerialNumber())
+		require.NoError(t, err)
+		var n *big.Int
+		_, err = asn1.Unmarshal(der, &n)
+		require.NoError(t, err)
+
+		parsed, err := xuid.FromSerialNumber(n, "csr")
+		require.NoError(t, err)
+		assert.True(t, id.Equal(parsed))
+	})
+
+	t.Run("is accepted as certificate serial number", func(t *testing.T) {
+		id := xuid.MustNewSortable("csr")
+		tmpl := &x509.Certificate{SerialNumber: id.SerialNumber()}
+		key, err := ecdsaKey()
+		require.NoError(t, err)
+
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+
+		parsed, err := xuid.FromSerialNumber(cert.SerialNumber, "csr")
+		require.NoError(t, err)
+		assert.True(t, id.Equal(parsed))
+	})
+
+	t.Run("rejects integers that do not hold a UUID", func(t *testing.T) {
+		tooBig := new(big.Int).Lsh(big.NewInt(1), 128)
+
+		for _, n := range []*big.Int{nil, big.NewInt(-1), tooBig} {
+			_, err := xuid.FromSerialNumber(n, "")
+
+			assert.ErrorIs(t, err, xuid.ErrInvalidSerial)
+		}
+	})
+}
+
+func ecdsaKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
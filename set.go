@@ -0,0 +1,57 @@
+package xuid
+
+import "encoding/json"
+
+// Set is a set of XUIDs. Two XUIDs are the same member when both their UUID
+// and prefix are equal.
+// Sets are encoded to JSON as arrays in the order defined by Compare, so the
+// output is deterministic.
+type Set map[XUID]struct{}
+
+func NewSet(ids ...XUID) Set {
+	s := make(Set, len(ids))
+	for _, id := range ids {
+		s.Add(id)
+	}
+	return s
+}
+
+func (s Set) Add(x XUID) {
+	s[x] = struct{}{}
+}
+
+func (s Set) Remove(x XUID) {
+	delete(s, x)
+}
+
+func (s Set) Has(x XUID) bool {
+	_, ok := s[x]
+	return ok
+}
+
+func (s Set) Len() int {
+	return len(s)
+}
+
+// Slice returns the members of the set in the order defined by Compare.
+func (s Set) Slice() []XUID {
+	res := make([]XUID, 0, len(s))
+	for x := range s {
+		res = append(res, x)
+	}
+	Sort(res)
+	return res
+}
+
+func (s Set) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Slice())
+}
+
+func (s *Set) UnmarshalJSON(data []byte) error {
+	var ids []XUID
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return err
+	}
+	*s = NewSet(ids...)
+	return nil
+}
@@ -0,0 +1,53 @@
+package xuid_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	t.Run("adds, checks and removes members", func(t *testing.T) {
+		id1 := xuid.MustNewSortable("user")
+		id2 := xuid.MustNewSortable("user")
+		s := xuid.NewSet(id1, id1)
+
+		assert.Equal(t, 1, s.Len())
+		assert.True(t, s.Has(id1))
+		assert.False(t, s.Has(id2))
+
+		s.Add(id2)
+		s.Remove(id1)
+		assert.False(t, s.Has(id1))
+		assert.True(t, s.Has(id2))
+	})
+
+	t.Run("marshals members deterministically", func(t *testing.T) {
+		ids := []xuid.XUID{
+			xuid.MustNewSortable("user"),
+			xuid.MustNewSortable("user"),
+			xuid.MustNewSortable("user"),
+		}
+		s := xuid.NewSet(ids[2], ids[0], ids[1])
+
+		data, err := json.Marshal(s)
+
+		require.NoError(t, err)
+		expected, _ := json.Marshal(ids)
+		assert.Equal(t, string(expected), string(data))
+	})
+
+	t.Run("round trips through JSON", func(t *testing.T) {
+		s := xuid.NewSet(xuid.MustNewSortable("user"), xuid.MustNewRandom("order"))
+
+		data, err := json.Marshal(s)
+		require.NoError(t, err)
+
+		var parsed xuid.Set
+		require.NoError(t, json.Unmarshal(data, &parsed))
+		assert.Equal(t, s, parsed)
+	})
+}
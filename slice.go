@@ -0,0 +1,59 @@
+package xuid
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// ParseSlice parses every string in idstrs.
+// Unlike Parse it does not stop at the first invalid string: it returns the
+// successfully parsed XUIDs in input order, along with an error joining one
+// entry per invalid string that reports its index and value.
+func ParseSlice(idstrs []string) ([]XUID, error) {
+	res := make([]XUID, 0, len(idstrs))
+	var errs []error
+	for i, s := range idstrs {
+		xid, err := Parse(s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("index %d (%q): %w", i, s, err))
+			continue
+		}
+		res = append(res, xid)
+	}
+	return res, errors.Join(errs...)
+}
+
+// FormatSlice returns the string forms of ids, as returned by String, for
+// bulk exports. The payloads are encoded two at a time, which lets the
+// processor overlap their arithmetic, and the strings share a single
+// buffer, so FormatSlice makes two allocations in total instead of one per
+// XUID, at the cost of keeping the whole buffer alive as long as any of the
+// strings is referenced. It is faster than calling String for each XUID,
+// but not by a multiple: String uses the same encoder, one UUID at a time.
+func FormatSlice(ids []XUID) []string {
+	n := 0
+	for _, x := range ids {
+		n += StringLen(x.prefix)
+	}
+	buf := make([]byte, 0, n)
+	res := make([]string, len(ids))
+	var d1, d2 base58Digits
+	i := 0
+	for ; i+1 < len(ids); i += 2 {
+		x1, x2 := &ids[i], &ids[i+1]
+		encodeBase58x2(&d1, &d2, &x1.uuid, &x2.uuid)
+		start := len(buf)
+		buf = appendDigits(x1.appendPrefix(buf), &d1, &x1.uuid)
+		res[i] = unsafe.String(&buf[start], len(buf)-start)
+		start = len(buf)
+		buf = appendDigits(x2.appendPrefix(buf), &d2, &x2.uuid)
+		res[i+1] = unsafe.String(&buf[start], len(buf)-start)
+	}
+	if i < len(ids) {
+		start := len(buf)
+		buf = ids[i].appendString(buf)
+		res[i] = unsafe.String(&buf[start], len(buf)-start)
+	}
+	return res
+}
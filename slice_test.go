@@ -0,0 +1,113 @@
+package xuid_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSlice(t *testing.T) {
+	t.Run("parses all valid strings", func(t *testing.T) {
+		id1 := xuid.MustNewSortable("user")
+		id2 := xuid.MustNewSortable("order")
+
+		ids, err := xuid.ParseSlice([]string{id1.String(), id2.String()})
+
+		require.NoError(t, err)
+		require.Len(t, ids, 2)
+		assert.True(t, id1.Equal(ids[0]))
+		assert.True(t, id2.Equal(ids[1]))
+	})
+
+	t.Run("returns valid subset and reports every invalid string", func(t *testing.T) {
+		id1 := xuid.MustNewSortable("user")
+		id2 := xuid.MustNewSortable("user")
+
+		ids, err := xuid.ParseSlice([]string{"bad_one", id1.String(), "", id2.String()})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, xuid.ErrParse)
+		assert.Contains(t, err.Error(), `index 0 ("bad_one")`)
+		assert.Contains(t, err.Error(), `index 2 ("")`)
+		require.Len(t, ids, 2)
+		assert.True(t, id1.Equal(ids[0]))
+		assert.True(t, id2.Equal(ids[1]))
+	})
+
+	t.Run("returns empty slice for empty input", func(t *testing.T) {
+		ids, err := xuid.ParseSlice(nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+}
+
+func TestFormatSlice(t *testing.T) {
+	t.Run("matches String", func(t *testing.T) {
+		nilID, _ := xuid.NilUUID()
+		ids := []xuid.XUID{xuid.MustNewSortable("user"), xuid.MustNewRandom(""), nilID, xuid.MustNewSortable("order_line")}
+
+		strs := xuid.FormatSlice(ids)
+
+		require.Len(t, strs, len(ids))
+		for i, x := range ids {
+			assert.Equal(t, x.String(), strs[i])
+		}
+	})
+
+	t.Run("matches String for odd counts and leading zero bytes", func(t *testing.T) {
+		withConfig(t, xuid.Config{Encoding: xuid.EncodingLengthPrefixed})
+		ids := make([]xuid.XUID, 7)
+		for i := range ids {
+			u := uuid.New()
+			for j := 0; j < i; j++ {
+				u[j] = 0
+			}
+			ids[i] = xuid.Must(xuid.NewWith(u, strings.Repeat("p", i)))
+		}
+
+		strs := xuid.FormatSlice(ids)
+
+		for i, x := range ids {
+			assert.Equal(t, x.String(), strs[i])
+		}
+	})
+
+	t.Run("returns empty slice for no XUIDs", func(t *testing.T) {
+		assert.Empty(t, xuid.FormatSlice(nil))
+	})
+}
+
+// benchIDs are the XUIDs of a bulk export in the FormatSlice benchmarks.
+func benchIDs() []xuid.XUID {
+	ids := make([]xuid.XUID, 1000)
+	for i := range ids {
+		ids[i] = xuid.MustNewSortable("user")
+	}
+	return ids
+}
+
+func BenchmarkFormatSlice(b *testing.B) {
+	ids := benchIDs()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = xuid.FormatSlice(ids)
+	}
+}
+
+// BenchmarkFormatSliceString is the per-ID String baseline for
+// BenchmarkFormatSlice.
+func BenchmarkFormatSliceString(b *testing.B) {
+	ids := benchIDs()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		strs := make([]string, len(ids))
+		for j, x := range ids {
+			strs[j] = x.String()
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package xuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
+// Compare returns -1, 0 or +1 depending on whether x sorts before, equal to
+// or after y. XUIDs are ordered by their UUID bytes, which is chronological
+// for sortable XUIDs, and then by prefix.
+func Compare(x, y XUID) int {
+	if c := bytes.Compare(x.uuid[:], y.uuid[:]); c != 0 {
+		return c
+	}
+	return strings.Compare(x.prefix, y.prefix)
+}
+
+// Sort sorts ids in place in the order defined by Compare.
+func Sort(ids []XUID) {
+	slices.SortFunc(ids, Compare)
+}
+
+// SearchSorted searches for x in ids, which must be sorted in the order
+// defined by Compare. It returns the position of x, or the position where x
+// would be inserted, and whether x was found. XUIDs are compared by their
+// bytes, without converting them to strings.
+func SearchSorted(ids []XUID, x XUID) (int, bool) {
+	return slices.BinarySearchFunc(ids, x, Compare)
+}
+
+// InsertSorted inserts x into ids, which must be sorted in the order
+// defined by Compare, keeping it sorted, and returns the updated slice.
+// ids is returned unchanged if it already contains x.
+func InsertSorted(ids []XUID, x XUID) []XUID {
+	i, found := SearchSorted(ids, x)
+	if found {
+		return ids
+	}
+	return slices.Insert(ids, i, x)
+}
+
+// SortedSlice is a []XUID that is encoded to JSON in the order defined by
+// Compare, regardless of the order of its elements. The slice itself is not
+// modified when marshaling.
+type SortedSlice []XUID
+
+func (s SortedSlice) MarshalJSON() ([]byte, error) {
+	ids := slices.Clone([]XUID(s))
+	Sort(ids)
+	if ids == nil {
+		ids = []XUID{}
+	}
+	return json.Marshal(ids)
+}
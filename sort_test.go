@@ -0,0 +1,120 @@
+package xuid_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	t.Run("orders sortable XUIDs chronologically", func(t *testing.T) {
+		id1 := xuid.MustNewSortable("user")
+		id2 := xuid.MustNewSortable("user")
+
+		assert.Equal(t, -1, xuid.Compare(id1, id2))
+		assert.Equal(t, 1, xuid.Compare(id2, id1))
+	})
+
+	t.Run("returns zero for equal XUIDs", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+
+		assert.Equal(t, 0, xuid.Compare(id, id))
+	})
+
+	t.Run("orders by prefix when UUIDs are equal", func(t *testing.T) {
+		u := uuid.New()
+		a, _ := xuid.NewWith(u, "a")
+		b, _ := xuid.NewWith(u, "b")
+
+		assert.Equal(t, -1, xuid.Compare(a, b))
+	})
+}
+
+func TestSortedSlice(t *testing.T) {
+	t.Run("marshals elements in sorted order without modifying slice", func(t *testing.T) {
+		id1 := xuid.MustNewSortable("user")
+		id2 := xuid.MustNewSortable("user")
+		s := xuid.SortedSlice{id2, id1}
+
+		data, err := json.Marshal(s)
+
+		require.NoError(t, err)
+		assert.Equal(t, `["`+id1.String()+`","`+id2.String()+`"]`, string(data))
+		assert.True(t, id2.Equal(s[0]))
+	})
+
+	t.Run("marshals nil slice as empty array", func(t *testing.T) {
+		data, err := json.Marshal(xuid.SortedSlice(nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, `[]`, string(data))
+	})
+
+	t.Run("unmarshals like a plain slice", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+
+		var s xuid.SortedSlice
+		err := json.Unmarshal([]byte(`["`+id.String()+`"]`), &s)
+
+		require.NoError(t, err)
+		require.Len(t, s, 1)
+		assert.True(t, id.Equal(s[0]))
+	})
+}
+
+func TestSearchSorted(t *testing.T) {
+	ids := make([]xuid.XUID, 20)
+	for i := range ids {
+		ids[i] = xuid.MustNewRandom("user")
+	}
+	xuid.Sort(ids)
+
+	t.Run("finds present XUIDs", func(t *testing.T) {
+		for i, x := range ids {
+			pos, found := xuid.SearchSorted(ids, x)
+
+			assert.True(t, found)
+			assert.Equal(t, i, pos)
+		}
+	})
+
+	t.Run("returns insertion position of missing XUIDs", func(t *testing.T) {
+		x := xuid.MustNewRandom("user")
+
+		pos, found := xuid.SearchSorted(ids, x)
+
+		assert.False(t, found)
+		if pos > 0 {
+			assert.Equal(t, -1, xuid.Compare(ids[pos-1], x))
+		}
+		if pos < len(ids) {
+			assert.Equal(t, 1, xuid.Compare(ids[pos], x))
+		}
+	})
+}
+
+func TestInsertSorted(t *testing.T) {
+	t.Run("keeps slice sorted", func(t *testing.T) {
+		var ids []xuid.XUID
+		for i := 0; i < 20; i++ {
+			ids = xuid.InsertSorted(ids, xuid.MustNewRandom("user"))
+		}
+
+		assert.Len(t, ids, 20)
+		assert.True(t, slices.IsSortedFunc(ids, xuid.Compare))
+	})
+
+	t.Run("does not insert duplicates", func(t *testing.T) {
+		x := xuid.MustNewRandom("user")
+		ids := xuid.InsertSorted(nil, x)
+
+		ids = xuid.InsertSorted(ids, x)
+
+		assert.Equal(t, []xuid.XUID{x}, ids)
+	})
+}
@@ -0,0 +1,55 @@
+package xuid
+
+import (
+	"strings"
+	"unicode"
+)
+
+// natoWords are the words of the NATO phonetic alphabet.
+var natoWords = [26]string{
+	"Alfa", "Bravo", "Charlie", "Delta", "Echo", "Foxtrot", "Golf", "Hotel",
+	"India", "Juliett", "Kilo", "Lima", "Mike", "November", "Oscar", "Papa",
+	"Quebec", "Romeo", "Sierra", "Tango", "Uniform", "Victor", "Whiskey",
+	"X-ray", "Yankee", "Zulu",
+}
+
+var digitWords = [10]string{"Zero", "One", "Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine"}
+
+// Spell returns the last n characters of the payload of x in the NATO
+// phonetic alphabet, followed by its check word, such as
+// "capital Kilo, bravo, Seven, check Whiskey", for support agents to
+// confirm IDs over the phone. Base58 is case-sensitive, so uppercase
+// letters are announced as capital. The check word, derived from the
+// whole UUID, catches IDs that only share their last characters; verify
+// what the caller reads back with VerifySpelling.
+func (x XUID) Spell(n int) string {
+	payload := x.payload()
+	payload = payload[len(payload)-max(min(n, len(payload)), 0):]
+	words := make([]string, 0, len(payload)+1)
+	for _, c := range payload {
+		switch {
+		case c >= '0' && c <= '9':
+			words = append(words, digitWords[c-'0'])
+		case c >= 'A' && c <= 'Z':
+			words = append(words, "capital "+natoWords[c-'A'])
+		default:
+			words = append(words, strings.ToLower(natoWords[c-'a']))
+		}
+	}
+	words = append(words, "check "+x.CheckWord())
+	return strings.Join(words, ", ")
+}
+
+// CheckWord returns the NATO word selected by the canonical hash of x.
+func (x XUID) CheckWord() string {
+	return natoWords[x.CanonicalHash()%uint64(len(natoWords))]
+}
+
+// VerifySpelling reports whether suffix, the characters read back by a
+// caller, ends the payload of x and checkWord is its check word. The check
+// word is compared case-insensitively and suffix must not be empty.
+func (x XUID) VerifySpelling(suffix, checkWord string) bool {
+	suffix = strings.TrimFunc(suffix, unicode.IsSpace)
+	return suffix != "" && strings.HasSuffix(x.payload(), suffix) &&
+		strings.EqualFold(strings.TrimSpace(checkWord), x.CheckWord())
+}
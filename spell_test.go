@@ -0,0 +1,36 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpell(t *testing.T) {
+	id := xuid.Must(xuid.NewWith(uuid.MustParse(xuid.CanonicalHashVectorUUID), "order")) // order_BzmjTFLHWXwiSK4y3H5iW
+
+	t.Run("spells the last characters and the check word", func(t *testing.T) {
+		assert.Equal(t, "capital Hotel, Five, india, capital Whiskey, check Oscar", id.Spell(4))
+	})
+
+	t.Run("clamps the number of characters", func(t *testing.T) {
+		assert.Equal(t, "check Oscar", id.Spell(0))
+		assert.Equal(t, id.Spell(21), id.Spell(100))
+	})
+
+	t.Run("derives the check word from the canonical hash", func(t *testing.T) {
+		assert.Equal(t, "Oscar", id.CheckWord())
+	})
+}
+
+func TestVerifySpelling(t *testing.T) {
+	id := xuid.Must(xuid.NewWith(uuid.MustParse(xuid.CanonicalHashVectorUUID), "order"))
+
+	assert.True(t, id.VerifySpelling("H5iW", "oscar"))
+	assert.True(t, id.VerifySpelling(" 3H5iW ", " Oscar"))
+	assert.False(t, id.VerifySpelling("H5IW", "Oscar"))
+	assert.False(t, id.VerifySpelling("H5iW", "Papa"))
+	assert.False(t, id.VerifySpelling("", "Oscar"))
+}
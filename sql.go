@@ -1,6 +1,7 @@
 package xuid
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"errors"
 
@@ -9,17 +10,52 @@ import (
 
 // Value implements the driver.Valuer interface.
 // This allows XUID to be stored in SQL databases as UUID.
+// The representation depends on Config.SQLStorage.
+//
+// Optional columns can be modeled as *XUID fields: database/sql stores a nil
+// *XUID as NULL and scans NULL into a nil pointer. Use PtrValue when calling
+// Value directly on a pointer that may be nil.
 func (x XUID) Value() (driver.Value, error) {
 	if x.uuid == uuid.Nil {
 		return nil, nil
 	}
+	switch GetConfig().SQLStorage {
+	case SQLStorageMSSQL:
+		return x.GUIDBytes(), nil
+	case SQLStorageBinary:
+		return x.uuid[:], nil
+	}
 	return x.uuid.String(), nil
 }
 
+// PtrValue returns the driver value of an optional XUID.
+// A nil pointer and a nil UUID are both stored as NULL.
+func PtrValue(x *XUID) (driver.Value, error) {
+	if x == nil {
+		return nil, nil
+	}
+	return x.Value()
+}
+
+// SplitForStorage returns the prefix and UUID of x, for schemas that store
+// them in separate columns. Unlike a single UUID column, a prefix column
+// keeps the prefix across Scan and can be indexed and queried, such as
+// "WHERE prefix = 'user'". Use JoinFromStorage to rebuild the XUID.
+func (x XUID) SplitForStorage() (string, uuid.UUID) {
+	return x.prefix, x.uuid
+}
+
+// JoinFromStorage rebuilds a XUID from the prefix and UUID columns written
+// with SplitForStorage. The prefix is validated as in NewWith.
+func JoinFromStorage(prefix string, u uuid.UUID) (XUID, error) {
+	return NewWith(u, prefix)
+}
+
 // Scan implements the sql.Scanner interface.
 // This allows XUID to be loaded from SQL databases.
 // Note: The prefix information is lost when loading from database.
 // You should reconstruct XUIDs with their appropriate prefixes after loading.
+// Byte slices are copied, so drivers may reuse their buffers after Scan.
 func (x *XUID) Scan(value interface{}) error {
 	if value == nil {
 		x.uuid = uuid.Nil
@@ -30,20 +66,44 @@ func (x *XUID) Scan(value interface{}) error {
 	switch d := value.(type) {
 	case string:
 		id, err := uuid.Parse(d)
-		if err != nil {
+		if err != nil || GetConfig().Strict && len(d) != 36 {
 			return errors.New("failed to scan from database. Invalid XUID string")
 		}
 		copy(x.uuid[:], id[:])
 		x.prefix = ""
 		return nil
 	case []byte:
-		if len(d) != 16 {
-			return errors.New("failed to scan from database. Invalid XUID bytes")
-		}
-		copy(x.uuid[:], d)
-		x.prefix = "" // Prefix is lost when loading from database
-		return nil
+		return x.ScanRaw(d)
+	case sql.RawBytes:
+		return x.ScanRaw(d)
 	}
 
 	return errors.New("unsupported type to scan as sql value")
 }
+
+// ScanRaw loads the 16 UUID bytes in b without allocating.
+// With SQLStorageMSSQL, b is expected in uniqueidentifier byte order.
+// b may also hold the 36-byte hyphenated UUID string, which drivers such
+// as lib/pq and go-sql-driver/mysql return for uuid and CHAR(36) columns;
+// the lengths cannot be confused, and malformed strings are rejected.
+// b is read but never retained, so it is safe to pass sql.RawBytes or any
+// other buffer that is reused after the call returns.
+func (x *XUID) ScanRaw(b []byte) error {
+	switch len(b) {
+	case 16:
+		copy(x.uuid[:], b)
+		if GetConfig().SQLStorage == SQLStorageMSSQL {
+			x.uuid = swapGUID(x.uuid)
+		}
+	case 36:
+		id, err := uuid.ParseBytes(b)
+		if err != nil {
+			return errors.New("failed to scan from database. Invalid XUID string")
+		}
+		x.uuid = id
+	default:
+		return errors.New("failed to scan from database. Invalid XUID bytes")
+	}
+	x.prefix = "" // Prefix is lost when loading from database
+	return nil
+}
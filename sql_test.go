@@ -3,6 +3,7 @@ package xuid_test
 import (
 	"database/sql"
 	"database/sql/driver"
+	"strings"
 	"testing"
 
 	"github.com/47monad/xuid"
@@ -245,3 +246,303 @@ func BenchmarkSQLOperations(b *testing.B) {
 		}
 	})
 }
+
+func TestXUIDPointerFields(t *testing.T) {
+	db := openEchoDB()
+	defer db.Close()
+
+	t.Run("PtrValue returns nil for nil pointer", func(t *testing.T) {
+		value, err := xuid.PtrValue(nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("PtrValue returns UUID string for non-nil pointer", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+
+		value, err := xuid.PtrValue(&id)
+
+		require.NoError(t, err)
+		assert.Equal(t, id.GetUUID().String(), value)
+	})
+
+	t.Run("stores nil pointer as NULL and scans it back as nil", func(t *testing.T) {
+		var in *xuid.XUID
+		out := &xuid.XUID{}
+
+		err := db.QueryRow("SELECT ?", in).Scan(&out)
+
+		require.NoError(t, err)
+		assert.Nil(t, out)
+	})
+
+	t.Run("stores non-nil pointer as UUID and scans it back", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+		in := &id
+		var out *xuid.XUID
+
+		err := db.QueryRow("SELECT ?", in).Scan(&out)
+
+		require.NoError(t, err)
+		require.NotNil(t, out)
+		assert.Equal(t, id.GetUUID(), out.GetUUID())
+	})
+}
+
+func TestXUIDScanRawBytes(t *testing.T) {
+	t.Run("Scan does not retain the source buffer", func(t *testing.T) {
+		testUUID := uuid.New()
+		buf := make([]byte, 16)
+		copy(buf, testUUID[:])
+		var id xuid.XUID
+
+		require.NoError(t, id.Scan(buf))
+		for i := range buf {
+			buf[i] = 0xff
+		}
+
+		assert.Equal(t, testUUID, id.GetUUID())
+	})
+
+	t.Run("Scan accepts sql.RawBytes", func(t *testing.T) {
+		testUUID := uuid.New()
+		var id xuid.XUID
+
+		err := id.Scan(sql.RawBytes(testUUID[:]))
+
+		require.NoError(t, err)
+		assert.Equal(t, testUUID, id.GetUUID())
+	})
+
+	t.Run("ScanRaw loads bytes and does not retain them", func(t *testing.T) {
+		testUUID := uuid.New()
+		buf := append([]byte(nil), testUUID[:]...)
+		id := xuid.MustNewSortable("user")
+
+		require.NoError(t, id.ScanRaw(buf))
+		buf[0] ^= 0xff
+
+		assert.Equal(t, testUUID, id.GetUUID())
+		assert.Equal(t, "", id.GetPrefix())
+	})
+
+	t.Run("ScanRaw rejects wrong length", func(t *testing.T) {
+		var id xuid.XUID
+
+		assert.Error(t, id.ScanRaw(make([]byte, 15)))
+	})
+
+	t.Run("scans driver-owned RawBytes rows", func(t *testing.T) {
+		db := openEchoDB()
+		defer db.Close()
+		testUUID := uuid.New()
+
+		rows, err := db.Query("SELECT ?", testUUID[:])
+		require.NoError(t, err)
+		defer rows.Close()
+		require.True(t, rows.Next())
+
+		var raw sql.RawBytes
+		require.NoError(t, rows.Scan(&raw))
+		var id xuid.XUID
+		require.NoError(t, id.ScanRaw(raw))
+
+		assert.Equal(t, testUUID, id.GetUUID())
+	})
+}
+
+func TestXUIDScanTextBytes(t *testing.T) {
+	testUUID := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+
+	// Values as returned by drivers for the column types storing UUIDs.
+	drivers := []struct {
+		name  string
+		value driver.Value
+	}{
+		{"lib/pq uuid", []byte("01890a5d-ac96-774b-bcce-b302099a8057")},
+		{"pgx uuid", "01890a5d-ac96-774b-bcce-b302099a8057"},
+		{"go-sql-driver/mysql CHAR(36)", []byte("01890a5d-ac96-774b-bcce-b302099a8057")},
+		{"go-sql-driver/mysql BINARY(16)", testUUID[:]},
+		{"mattn/go-sqlite3 TEXT", []byte("01890a5d-ac96-774b-bcce-b302099a8057")},
+		{"mattn/go-sqlite3 BLOB", testUUID[:]},
+		{"go-mssqldb uniqueidentifier cast to text", []byte("01890A5D-AC96-774B-BCCE-B302099A8057")},
+	}
+	for _, d := range drivers {
+		t.Run("scans "+d.name, func(t *testing.T) {
+			db := openEchoDB()
+			defer db.Close()
+
+			var id xuid.XUID
+			err := db.QueryRow("SELECT ?", d.value).Scan(&id)
+
+			require.NoError(t, err)
+			assert.Equal(t, testUUID, id.GetUUID())
+		})
+	}
+
+	t.Run("does not swap text with SQLStorageMSSQL", func(t *testing.T) {
+		withConfig(t, xuid.Config{SQLStorage: xuid.SQLStorageMSSQL})
+		var id xuid.XUID
+
+		require.NoError(t, id.Scan([]byte("01890A5D-AC96-774B-BCCE-B302099A8057")))
+
+		assert.Equal(t, testUUID, id.GetUUID())
+	})
+
+	t.Run("rejects malformed text", func(t *testing.T) {
+		for _, b := range []string{
+			"01890a5d-ac96-774b-bcce-b302099a805g",
+			"01890a5dxac96-774b-bcce-b302099a8057",
+			"01890a5d-ac96-774b-bcce-b302099a80",
+			"01890a5dac96774bbcceb302099a8057",
+		} {
+			var id xuid.XUID
+
+			err := id.Scan([]byte(b))
+
+			assert.Error(t, err, b)
+		}
+	})
+}
+
+func TestSQLStorageMSSQL(t *testing.T) {
+	// NEWID() value 6F9619FF-8B86-D011-B42D-00C04FC964FF as stored by SQL Server.
+	testUUID := uuid.MustParse("6F9619FF-8B86-D011-B42D-00C04FC964FF")
+	stored := []byte{0xff, 0x19, 0x96, 0x6f, 0x86, 0x8b, 0x11, 0xd0, 0xb4, 0x2d, 0x00, 0xc0, 0x4f, 0xc9, 0x64, 0xff}
+
+	t.Run("Value writes uniqueidentifier bytes", func(t *testing.T) {
+		withConfig(t, xuid.Config{SQLStorage: xuid.SQLStorageMSSQL})
+		id, err := xuid.NewWith(testUUID, "user")
+		require.NoError(t, err)
+
+		value, err := id.Value()
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, value)
+	})
+
+	t.Run("Scan reads uniqueidentifier bytes", func(t *testing.T) {
+		withConfig(t, xuid.Config{SQLStorage: xuid.SQLStorageMSSQL})
+		var id xuid.XUID
+
+		require.NoError(t, id.Scan(stored))
+
+		assert.Equal(t, testUUID, id.GetUUID())
+	})
+
+	t.Run("Scan still reads strings", func(t *testing.T) {
+		withConfig(t, xuid.Config{SQLStorage: xuid.SQLStorageMSSQL})
+		var id xuid.XUID
+
+		require.NoError(t, id.Scan("6F9619FF-8B86-D011-B42D-00C04FC964FF"))
+
+		assert.Equal(t, testUUID, id.GetUUID())
+	})
+
+	t.Run("round-trips through database/sql", func(t *testing.T) {
+		withConfig(t, xuid.Config{SQLStorage: xuid.SQLStorageMSSQL})
+		db := openEchoDB()
+		defer db.Close()
+		original := xuid.MustNewSortable("user")
+
+		var loaded xuid.XUID
+		require.NoError(t, db.QueryRow("SELECT ?", original).Scan(&loaded))
+
+		assert.Equal(t, original.GetUUID(), loaded.GetUUID())
+	})
+
+	t.Run("nil UUID is stored as NULL", func(t *testing.T) {
+		withConfig(t, xuid.Config{SQLStorage: xuid.SQLStorageMSSQL})
+		id, _ := xuid.NilUUID()
+
+		value, err := id.Value()
+
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+}
+
+func TestSQLStorageBinary(t *testing.T) {
+	t.Run("Value writes RFC 4122 bytes", func(t *testing.T) {
+		withConfig(t, xuid.Config{SQLStorage: xuid.SQLStorageBinary})
+		id := xuid.MustNewSortable("user")
+		want := id.GetUUID()
+
+		value, err := id.Value()
+
+		require.NoError(t, err)
+		assert.Equal(t, want[:], value)
+	})
+
+	t.Run("round-trips through database/sql", func(t *testing.T) {
+		withConfig(t, xuid.Config{SQLStorage: xuid.SQLStorageBinary})
+		db := openEchoDB()
+		defer db.Close()
+		original := xuid.MustNewSortable("user")
+
+		var loaded xuid.XUID
+		require.NoError(t, db.QueryRow("SELECT ?", original).Scan(&loaded))
+
+		assert.Equal(t, original.GetUUID(), loaded.GetUUID())
+	})
+
+	t.Run("Scan reads RAWTOHEX strings", func(t *testing.T) {
+		original := xuid.MustNewSortable("user")
+		var loaded xuid.XUID
+
+		require.NoError(t, loaded.Scan(original.HexRaw()))
+
+		assert.Equal(t, original.GetUUID(), loaded.GetUUID())
+	})
+}
+
+func BenchmarkScanRaw(b *testing.B) {
+	id := xuid.MustNewSortable("bench")
+	raw := id.GetUUID()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var xid xuid.XUID
+		_ = xid.ScanRaw(raw[:])
+	}
+}
+
+func TestSplitForStorage(t *testing.T) {
+	t.Run("splits and joins prefix and UUID", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+
+		prefix, u := x.SplitForStorage()
+		joined, err := xuid.JoinFromStorage(prefix, u)
+
+		require.NoError(t, err)
+		assert.Equal(t, "user", prefix)
+		assert.Equal(t, x.GetUUID(), u)
+		assert.Equal(t, x, joined)
+	})
+
+	t.Run("keeps the prefix through database/sql", func(t *testing.T) {
+		db := openEchoDB()
+		defer db.Close()
+		x := xuid.MustNewSortable("user")
+		prefix, u := x.SplitForStorage()
+
+		var (
+			gotPrefix string
+			gotUUID   uuid.UUID
+		)
+		err := db.QueryRow("SELECT prefix, id FROM t", prefix, u).Scan(&gotPrefix, &gotUUID)
+		require.NoError(t, err)
+		joined, err := xuid.JoinFromStorage(gotPrefix, gotUUID)
+
+		require.NoError(t, err)
+		assert.Equal(t, x, joined)
+	})
+
+	t.Run("validates the prefix", func(t *testing.T) {
+		_, err := xuid.JoinFromStorage(strings.Repeat("a", xuid.MaxPrefixLen+1), uuid.New())
+
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+	})
+}
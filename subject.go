@@ -0,0 +1,45 @@
+package xuid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultSubjectSeparator is used by SubjectString when
+// Config.SubjectSeparator is empty.
+const DefaultSubjectSeparator = ":"
+
+// SubjectString returns the subject of x for policy engines such as Casbin,
+// in the form "prefix:payload". The separator is Config.SubjectSeparator,
+// so that ACL rows written by different services match.
+// XUIDs without a prefix yield the bare payload.
+func (x XUID) SubjectString() string {
+	payload := x.payload()
+	if x.prefix == "" {
+		return payload
+	}
+	return x.prefix + subjectSeparator() + payload
+}
+
+// ParseSubject is the inverse of SubjectString.
+func ParseSubject(s string) (XUID, error) {
+	prefix, payload := "", s
+	if i := strings.LastIndex(s, subjectSeparator()); i >= 0 {
+		prefix, payload = s[:i], s[i+len(subjectSeparator()):]
+	}
+	p, id, err := parse(payload)
+	if err != nil {
+		return XUID{}, err
+	}
+	if p != "" {
+		return XUID{}, fmt.Errorf("%w: %q is not a subject", ErrParse, s)
+	}
+	return NewWith(id, prefix)
+}
+
+func subjectSeparator() string {
+	if sep := GetConfig().SubjectSeparator; sep != "" {
+		return sep
+	}
+	return DefaultSubjectSeparator
+}
@@ -0,0 +1,53 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubjectString(t *testing.T) {
+	id := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+	payload := xuid.Must(xuid.NewWith(id, "")).String()
+	x := xuid.Must(xuid.NewWith(id, "user"))
+
+	t.Run("joins prefix and payload with colon", func(t *testing.T) {
+		assert.Equal(t, "user:"+payload, x.SubjectString())
+	})
+
+	t.Run("omits empty prefix", func(t *testing.T) {
+		assert.Equal(t, payload, xuid.Must(xuid.NewWith(id, "")).SubjectString())
+	})
+
+	t.Run("uses configured separator", func(t *testing.T) {
+		withConfig(t, xuid.Config{SubjectSeparator: "/"})
+
+		assert.Equal(t, "user/"+payload, x.SubjectString())
+
+		parsed, err := xuid.ParseSubject("user/" + payload)
+		require.NoError(t, err)
+		assert.True(t, x.Equal(parsed))
+	})
+
+	t.Run("ParseSubject round-trips", func(t *testing.T) {
+		for _, prefix := range []string{"user", "user_profile", "org:user", ""} {
+			y := xuid.Must(xuid.NewWith(id, prefix))
+
+			parsed, err := xuid.ParseSubject(y.SubjectString())
+
+			require.NoError(t, err, prefix)
+			assert.True(t, y.Equal(parsed), prefix)
+		}
+	})
+
+	t.Run("ParseSubject rejects other strings", func(t *testing.T) {
+		for _, s := range []string{"", "user:", "user:0OIl", x.String(), "user:user_" + payload} {
+			_, err := xuid.ParseSubject(s)
+
+			assert.ErrorIs(t, err, xuid.ErrParse, s)
+		}
+	})
+}
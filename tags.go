@@ -0,0 +1,179 @@
+package xuid
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// tagTarget is implemented by *XUID and by pointers to types embedding XUID.
+type tagTarget interface {
+	SetPrefix(prefix string) *XUID
+	GetUUID() uuid.UUID
+	GetPrefix() string
+}
+
+var tagTargetType = reflect.TypeOf((*tagTarget)(nil)).Elem()
+
+// tagOptions are the options of a xuid struct tag.
+type tagOptions struct {
+	prefix    string
+	hasPrefix bool
+	require   bool
+}
+
+// DecodeJSON unmarshals data into dst with encoding/json, then applies the
+// xuid struct tags of dst as ApplyTags does.
+func DecodeJSON(data []byte, dst any) error {
+	if err := json.Unmarshal(data, dst); err != nil {
+		return err
+	}
+	return ApplyTags(dst)
+}
+
+// ApplyTags enforces the per-field prefix policy declared by xuid struct
+// tags in dst, a non-nil pointer, after it was filled by a decoder or by
+// SQL scanning:
+//
+//	type Membership struct {
+//		UserID xuid.XUID   `json:"user_id" xuid:"prefix=user,require"`
+//		OrgID  *xuid.XUID  `json:"org_id" xuid:"prefix=org"`
+//		Teams  []xuid.XUID `json:"teams" xuid:"prefix=team"`
+//	}
+//
+// The prefix option attaches the prefix to XUIDs without one and fails with
+// ErrPrefixMismatch for XUIDs with another prefix. The require option fails
+// with ErrMissingXUID for nil XUIDs and nil pointers. Options apply to each
+// element of tagged slices. Tags apply to fields of type XUID, types
+// embedding it, and pointers and slices of those, in nested structs too.
+// Errors name the offending field.
+//
+// After SQL scanning, which loses prefixes, the prefix option restores
+// them; xuidsql.RestorePrefixes applies them too for sqlx destinations.
+func ApplyTags(dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return ErrInvalidDest
+	}
+	return applyTags(v, "", map[tagVisit]bool{})
+}
+
+// tagVisit identifies a pointer followed by applyTags, so cyclic data is
+// walked once.
+type tagVisit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func applyTags(v reflect.Value, path string, seen map[tagVisit]bool) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		visit := tagVisit{v.Pointer(), v.Type()}
+		if seen[visit] {
+			return nil
+		}
+		seen[visit] = true
+		return applyTags(v.Elem(), path, seen)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := applyTags(v.Index(i), path+"["+strconv.Itoa(i)+"]", seen); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if reflect.PointerTo(v.Type()).Implements(tagTargetType) {
+			return nil
+		}
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			if !sf.IsExported() && !sf.Anonymous {
+				continue
+			}
+			fpath := sf.Name
+			if path != "" {
+				fpath = path + "." + sf.Name
+			}
+			if tag, ok := sf.Tag.Lookup("xuid"); ok && sf.IsExported() {
+				opts, err := parseTag(tag)
+				if err != nil {
+					return fmt.Errorf("field %s: %w", fpath, err)
+				}
+				if err := opts.apply(v.Field(i), fpath); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := applyTags(v.Field(i), fpath, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func parseTag(tag string) (tagOptions, error) {
+	var opts tagOptions
+	for _, opt := range strings.Split(tag, ",") {
+		switch {
+		case opt == "require":
+			opts.require = true
+		case strings.HasPrefix(opt, "prefix="):
+			opts.prefix, opts.hasPrefix = opt[len("prefix="):], true
+		default:
+			return opts, fmt.Errorf("unknown xuid tag option %q", opt)
+		}
+	}
+	return opts, nil
+}
+
+// apply enforces opts on v, the value of a tagged field or of one of its
+// elements.
+func (opts tagOptions) apply(v reflect.Value, path string) error {
+	switch {
+	case v.CanAddr() && v.Addr().Type().Implements(tagTargetType):
+		return opts.check(v.Addr().Interface().(tagTarget), path)
+	case v.Kind() == reflect.Pointer && v.Type().Implements(tagTargetType):
+		if v.IsNil() {
+			if opts.require {
+				return fmt.Errorf("field %s: %w", path, ErrMissingXUID)
+			}
+			return nil
+		}
+		return opts.check(v.Interface().(tagTarget), path)
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := opts.apply(v.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("field %s: xuid tag on unsupported type %s", path, v.Type())
+}
+
+func (opts tagOptions) check(t tagTarget, path string) error {
+	if t.GetUUID() == uuid.Nil {
+		if opts.require {
+			return fmt.Errorf("field %s: %w", path, ErrMissingXUID)
+		}
+		return nil
+	}
+	if !opts.hasPrefix {
+		return nil
+	}
+	switch t.GetPrefix() {
+	case opts.prefix:
+	case "":
+		t.SetPrefix(opts.prefix)
+	default:
+		return fmt.Errorf("field %s: %w: got %q, want %q", path, ErrPrefixMismatch, t.GetPrefix(), opts.prefix)
+	}
+	return nil
+}
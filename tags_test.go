@@ -0,0 +1,109 @@
+package xuid_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type taggedTeam struct {
+	Members []xuid.XUID `json:"members" xuid:"prefix=user"`
+}
+
+type taggedMembership struct {
+	UserID xuid.XUID  `json:"user_id" xuid:"prefix=user,require"`
+	OrgID  *xuid.XUID `json:"org_id" xuid:"prefix=org"`
+	Team   taggedTeam `json:"team"`
+	Other  xuid.XUID  `json:"other"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	user, org := xuid.MustNewSortable("user"), xuid.MustNewSortable("org")
+	bare := xuid.Must(xuid.NewWith(user.GetUUID(), ""))
+
+	t.Run("attaches missing prefixes", func(t *testing.T) {
+		data, _ := json.Marshal(map[string]any{"user_id": bare, "org_id": org, "team": map[string]any{"members": []xuid.XUID{bare, user}}})
+		var m taggedMembership
+
+		require.NoError(t, xuid.DecodeJSON(data, &m))
+
+		assert.Equal(t, user, m.UserID)
+		assert.Equal(t, org, *m.OrgID)
+		assert.Equal(t, []xuid.XUID{user, user}, m.Team.Members)
+	})
+
+	t.Run("rejects other prefixes", func(t *testing.T) {
+		data, _ := json.Marshal(map[string]any{"user_id": user, "team": map[string]any{"members": []xuid.XUID{org}}})
+		var m taggedMembership
+
+		err := xuid.DecodeJSON(data, &m)
+
+		assert.ErrorIs(t, err, xuid.ErrPrefixMismatch)
+		assert.Contains(t, err.Error(), "Team.Members[0]")
+	})
+
+	t.Run("requires required XUIDs", func(t *testing.T) {
+		var m taggedMembership
+
+		err := xuid.DecodeJSON([]byte(`{}`), &m)
+
+		assert.ErrorIs(t, err, xuid.ErrMissingXUID)
+		assert.Contains(t, err.Error(), "UserID")
+	})
+
+	t.Run("leaves untagged fields alone", func(t *testing.T) {
+		data, _ := json.Marshal(map[string]any{"user_id": user, "other": org})
+		var m taggedMembership
+
+		require.NoError(t, xuid.DecodeJSON(data, &m))
+
+		assert.Equal(t, org, m.Other)
+		assert.Nil(t, m.OrgID)
+	})
+
+	t.Run("reports decoding errors", func(t *testing.T) {
+		var m taggedMembership
+
+		assert.Error(t, xuid.DecodeJSON([]byte(`{"user_id":1}`), &m))
+	})
+}
+
+func TestApplyTags(t *testing.T) {
+	t.Run("applies to slices of structs", func(t *testing.T) {
+		ms := []taggedMembership{{UserID: xuid.MustNewSortable("")}, {}}
+
+		err := xuid.ApplyTags(&ms)
+
+		assert.ErrorIs(t, err, xuid.ErrMissingXUID)
+		assert.Contains(t, err.Error(), "[1].UserID")
+		assert.Equal(t, "user", ms[0].UserID.GetPrefix())
+	})
+
+	t.Run("walks cyclic data once", func(t *testing.T) {
+		type node struct {
+			ID     xuid.XUID `xuid:"prefix=node"`
+			Parent *node
+		}
+		n := &node{ID: xuid.MustNewSortable("")}
+		n.Parent = n
+
+		require.NoError(t, xuid.ApplyTags(n))
+
+		assert.Equal(t, "node", n.ID.GetPrefix())
+	})
+
+	t.Run("rejects non-pointers", func(t *testing.T) {
+		assert.ErrorIs(t, xuid.ApplyTags(taggedMembership{}), xuid.ErrInvalidDest)
+	})
+
+	t.Run("rejects unknown options", func(t *testing.T) {
+		var v struct {
+			ID xuid.XUID `xuid:"prefix=user,requried"`
+		}
+
+		assert.ErrorContains(t, xuid.ApplyTags(&v), "requried")
+	})
+}
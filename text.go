@@ -0,0 +1,13 @@
+package xuid
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// This allows XUID to be used as a map key with encoding/json and other
+// text based encoders.
+func (x XUID) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (x *XUID) UnmarshalText(data []byte) error {
+	return ParseInto(x, string(data))
+}
@@ -0,0 +1,80 @@
+package xuid_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextMarshaling(t *testing.T) {
+	t.Run("marshals XUID to its string form", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+
+		data, err := id.MarshalText()
+
+		require.NoError(t, err)
+		assert.Equal(t, id.String(), string(data))
+	})
+
+	t.Run("unmarshals text to XUID", func(t *testing.T) {
+		original := xuid.MustNewSortable("user")
+
+		var parsed xuid.XUID
+		err := parsed.UnmarshalText([]byte(original.String()))
+
+		require.NoError(t, err)
+		assert.True(t, original.Equal(parsed))
+	})
+
+	t.Run("returns error for invalid text", func(t *testing.T) {
+		var parsed xuid.XUID
+		err := parsed.UnmarshalText([]byte("invalid_string"))
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+
+	t.Run("implements encoding interfaces", func(t *testing.T) {
+		var _ encoding.TextMarshaler = xuid.XUID{}
+		var _ encoding.TextUnmarshaler = (*xuid.XUID)(nil)
+	})
+}
+
+func TestJSONMapKeys(t *testing.T) {
+	t.Run("marshals map keyed by XUID to object with prefixed keys", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+		m := map[xuid.XUID]int{id: 42}
+
+		data, err := json.Marshal(m)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"`+id.String()+`":42}`, string(data))
+	})
+
+	t.Run("round trips map keyed by XUID", func(t *testing.T) {
+		m := map[xuid.XUID]string{
+			xuid.MustNewSortable("user"):  "alice",
+			xuid.MustNewSortable("order"): "first",
+			xuid.MustNewRandom(""):        "anonymous",
+		}
+
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+
+		var parsed map[xuid.XUID]string
+		err = json.Unmarshal(data, &parsed)
+		require.NoError(t, err)
+
+		assert.Equal(t, m, parsed)
+	})
+
+	t.Run("returns error for invalid map key", func(t *testing.T) {
+		var parsed map[xuid.XUID]string
+		err := json.Unmarshal([]byte(`{"invalid_key":"value"}`), &parsed)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,26 @@
+package xuid
+
+import "github.com/google/uuid"
+
+// TombstoneUUID is the UUID of tombstone XUIDs. It is a UUIDv5 derived from
+// NamespaceXUID, so it is distinct from uuid.Nil and uuid.Max and never
+// produced by the random or sortable constructors.
+var TombstoneUUID = uuid.NewSHA1(NamespaceXUID, []byte("tombstone"))
+
+// Tombstone returns the sentinel XUID standing for a deleted or anonymized
+// entity, such as the owner of records kept after a GDPR erasure.
+// Unlike the nil UUID, a tombstone is a real value: it marshals to JSON as
+// a regular XUID string and is stored by Value as a regular UUID, so it can
+// replace IDs in NOT NULL columns.
+func Tombstone(prefix string) (XUID, error) {
+	return NewWith(TombstoneUUID, prefix)
+}
+
+func MustTombstone(prefix string) XUID {
+	return Must(Tombstone(prefix))
+}
+
+// IsTombstone reports whether x is a tombstone, whatever its prefix.
+func (x XUID) IsTombstone() bool {
+	return x.uuid == TombstoneUUID
+}
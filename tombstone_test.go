@@ -0,0 +1,53 @@
+package xuid_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTombstone(t *testing.T) {
+	t.Run("is distinct from nil and max", func(t *testing.T) {
+		x := xuid.MustTombstone("user")
+
+		assert.True(t, x.IsTombstone())
+		assert.False(t, xuid.IsEmpty(x))
+		assert.NotEqual(t, uuid.Max, x.GetUUID())
+		assert.Equal(t, "user", x.GetPrefix())
+	})
+
+	t.Run("regular XUIDs are not tombstones", func(t *testing.T) {
+		assert.False(t, xuid.MustNewSortable("user").IsTombstone())
+		assert.False(t, xuid.XUID{}.IsTombstone())
+	})
+
+	t.Run("round-trips through JSON", func(t *testing.T) {
+		data, err := json.Marshal(xuid.MustTombstone("user"))
+		require.NoError(t, err)
+
+		var x xuid.XUID
+		require.NoError(t, json.Unmarshal(data, &x))
+		assert.True(t, x.IsTombstone())
+		assert.Equal(t, "user", x.GetPrefix())
+	})
+
+	t.Run("round-trips through SQL", func(t *testing.T) {
+		v, err := xuid.MustTombstone("user").Value()
+		require.NoError(t, err)
+		require.NotNil(t, v)
+
+		var x xuid.XUID
+		require.NoError(t, x.Scan(v))
+		assert.True(t, x.IsTombstone())
+	})
+
+	t.Run("rejects invalid prefix", func(t *testing.T) {
+		_, err := xuid.Tombstone(string(make([]byte, xuid.MaxPrefixLen+1)))
+
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+	})
+}
@@ -0,0 +1,8 @@
+package xuid
+
+// URN returns the UUID in RFC 4122 URN form, "urn:uuid:" followed by the
+// hyphenated UUID, as required by specs such as SCIM and CalDAV.
+// The prefix is not part of the URN; use GetPrefix to carry it separately.
+func (x XUID) URN() string {
+	return x.uuid.URN()
+}
@@ -0,0 +1,29 @@
+package xuid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURN(t *testing.T) {
+	t.Run("formats UUID as URN", func(t *testing.T) {
+		id := uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057")
+		x, err := xuid.NewWith(id, "user")
+		require.NoError(t, err)
+
+		assert.Equal(t, "urn:uuid:01890a5d-ac96-774b-bcce-b302099a8057", x.URN())
+	})
+
+	t.Run("URN can be parsed back into the UUID", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+
+		id, err := uuid.Parse(x.URN())
+
+		require.NoError(t, err)
+		assert.Equal(t, x.GetUUID(), id)
+	})
+}
@@ -21,10 +21,15 @@
 package xuid
 
 import (
+	"crypto/rand"
+	"encoding/binary"
 	"errors"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
+	"unsafe"
 
-	"github.com/btcsuite/btcd/btcutil/base58"
 	"github.com/google/uuid"
 )
 
@@ -39,6 +44,12 @@ func New() (XUID, error) {
 }
 
 func NewWith(id uuid.UUID, prefix string) (XUID, error) {
+	if len(prefix) > MaxPrefixLen {
+		return XUID{}, ErrPrefixTooLong
+	}
+	if GetConfig().forbidsSeparator() && strings.Contains(prefix, Separator) {
+		return XUID{}, ErrPrefixSeparator
+	}
 	return XUID{
 		uuid:   id,
 		prefix: prefix,
@@ -48,27 +59,44 @@ func NewWith(id uuid.UUID, prefix string) (XUID, error) {
 func NewSortable(prefix string) (XUID, error) {
 	id, err := uuid.NewV7()
 	if err != nil {
-		return XUID{}, err
+		c := GetConfig()
+		if c.SortableFallback != SortableFallbackRandom {
+			return XUID{}, err
+		}
+		if c.OnSortableFallback != nil {
+			c.OnSortableFallback(err)
+		}
+		return NewRandom(prefix)
 	}
-	return XUID{
-		uuid:   id,
-		prefix: prefix,
-	}, nil
+	return NewWith(id, prefix)
 }
 
 func MustNewSortable(prefix string) XUID {
 	return Must(NewSortable(prefix))
 }
 
+// NewSortableAt creates a sortable XUID whose embedded timestamp is t
+// instead of the current time. The remaining bits are random, so IDs
+// created for the same millisecond are not ordered among themselves.
+func NewSortableAt(prefix string, t time.Time) (XUID, error) {
+	var id uuid.UUID
+	if _, err := rand.Read(id[6:]); err != nil {
+		return XUID{}, err
+	}
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(t.UnixMilli()))
+	copy(id[:6], ms[2:])
+	id[6] = (id[6] & 0x0f) | 0x70 // version 7
+	id[8] = (id[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return NewWith(id, prefix)
+}
+
 func NewRandom(prefix string) (XUID, error) {
 	id, err := uuid.NewRandom()
 	if err != nil {
 		return XUID{}, err
 	}
-	return XUID{
-		uuid:   id,
-		prefix: prefix,
-	}, nil
+	return NewWith(id, prefix)
 }
 
 func MustNewRandom(prefix string) XUID {
@@ -91,23 +119,65 @@ func (x XUID) IsRandom() bool {
 	return x.GetUUID().Version().String() == "VERSION_4"
 }
 
+// Time returns the creation time embedded in a sortable XUID, with
+// millisecond precision. It fails with ErrNotSortable for other versions.
+func (x XUID) Time() (time.Time, error) {
+	if !x.IsSortable() {
+		return time.Time{}, ErrNotSortable
+	}
+	return v7Time(x.uuid), nil
+}
+
 func (x XUID) GetPrefix() string {
 	return x.prefix
 }
 
 // SetPrefix sets the prefix field to the specified prefix.
 // This is useful when loading XUIDs from database and need to restore the prefix.
+// SetPrefix does not validate the prefix, even with Config.Strict; use
+// SetValidPrefix to do so.
 func (x *XUID) SetPrefix(prefix string) *XUID {
 	x.prefix = prefix
 	return x
 }
 
+// SetValidPrefix sets the prefix of x after validating it as in NewWith.
+// x is left unchanged when the prefix is invalid.
+func (x *XUID) SetValidPrefix(prefix string) error {
+	if _, err := NewWith(x.uuid, prefix); err != nil {
+		return err
+	}
+	x.prefix = prefix
+	return nil
+}
+
 func (x XUID) String() string {
-	b, _ := x.uuid.MarshalBinary()
-	if x.prefix == "" {
-		return base58.Encode(b)
+	b := x.appendString(make([]byte, 0, StringLen(x.prefix)))
+	return unsafe.String(&b[0], len(b))
+}
+
+// appendString appends the string form of x to dst.
+func (x XUID) appendString(dst []byte) []byte {
+	return appendBase58(x.appendPrefix(dst), x.uuid)
+}
+
+// payload returns the base58 payload of x.
+func (x XUID) payload() string {
+	return string(appendBase58(nil, x.uuid))
+}
+
+// appendPrefix appends the part of the string form of x before the
+// payload to dst.
+func (x XUID) appendPrefix(dst []byte) []byte {
+	if x.prefix != "" {
+		if GetConfig().Encoding == EncodingLengthPrefixed {
+			dst = strconv.AppendInt(dst, int64(len(x.prefix)), 10)
+			dst = append(dst, LengthMarker...)
+		}
+		dst = append(dst, x.prefix...)
+		dst = append(dst, Separator...)
 	}
-	return x.prefix + "_" + base58.Encode(b)
+	return dst
 }
 
 func (x XUID) Equal(y XUID) bool {
@@ -115,18 +185,133 @@ func (x XUID) Equal(y XUID) bool {
 }
 
 func Parse(idstr string) (XUID, error) {
-	underscoreIndex := strings.LastIndex(idstr, "_")
-	uuidstr := idstr[underscoreIndex+1:]
-	prefix := ""
-	if underscoreIndex >= 0 {
-		prefix = idstr[:underscoreIndex]
+	prefix, id, err := parse(idstr)
+	if err != nil {
+		return XUID{}, err
 	}
-	_str := base58.Decode(uuidstr)
-	_uuid, err := uuid.FromBytes(_str)
+	return NewWith(id, prefix)
+}
+
+// ParseInto parses idstr into dst, which lets callers reuse a XUID across
+// calls in tight loops. dst is left unchanged when parsing fails.
+func ParseInto(dst *XUID, idstr string) error {
+	prefix, id, err := parse(idstr)
 	if err != nil {
-		return XUID{}, ErrParse
+		return err
+	}
+	dst.uuid = id
+	dst.prefix = prefix
+	return nil
+}
+
+// parse splits idstr into its prefix and decoded UUID, falling back to the
+// legacy layout selected by Config.LegacyLayout unless Config.Strict is set.
+func parse(idstr string) (string, uuid.UUID, error) {
+	prefix, _, id, err := parseParts(idstr)
+	return prefix, id, err
+}
+
+// parseParts is parse but also returns the payload, from whichever layout
+// idstr is in.
+func parseParts(idstr string) (prefix, payload string, id uuid.UUID, err error) {
+	prefix, payload, id, err = parseCurrent(idstr)
+	if c := GetConfig(); err != nil && c.LegacyLayout == LegacyPrefixSuffix && !c.Strict {
+		if prefix, payload, id, ok := parseLegacySuffix(idstr); ok {
+			return prefix, payload, id, nil
+		}
+	}
+	return prefix, payload, id, err
+}
+
+// parseLegacySuffix parses idstr in the "payload_prefix" layout.
+func parseLegacySuffix(idstr string) (string, string, uuid.UUID, bool) {
+	i := strings.Index(idstr, Separator)
+	if i < 0 {
+		return "", "", uuid.Nil, false
+	}
+	prefix := idstr[i+len(Separator):]
+	if prefix == "" || len(prefix) > MaxPrefixLen {
+		return "", "", uuid.Nil, false
+	}
+	if GetConfig().forbidsSeparator() && strings.Contains(prefix, Separator) {
+		return "", "", uuid.Nil, false
+	}
+	_, payload, id, err := parseCurrent(idstr[:i])
+	if err != nil {
+		return "", "", uuid.Nil, false
+	}
+	return internPrefix(prefix), payload, id, true
+}
+
+// parseCurrent parses idstr in the layout selected by Config.Encoding and
+// returns its prefix, payload and UUID.
+func parseCurrent(idstr string) (string, string, uuid.UUID, error) {
+	var (
+		prefix          string
+		underscoreIndex int
+		err             error
+	)
+	if GetConfig().Encoding == EncodingLengthPrefixed {
+		prefix, underscoreIndex, err = splitLengthPrefixed(idstr)
+		if err != nil {
+			return "", "", uuid.Nil, err
+		}
+	} else {
+		underscoreIndex = strings.LastIndex(idstr, Separator)
+		if underscoreIndex >= 0 {
+			prefix = idstr[:underscoreIndex]
+		}
+	}
+	uuidstr := idstr[underscoreIndex+1:]
+	if len(prefix) > MaxPrefixLen {
+		return "", "", uuid.Nil, &ParseError{Input: idstr, Part: PartPrefix, Offset: -1, Reason: "is too long"}
+	}
+	if GetConfig().forbidsSeparator() {
+		if i := strings.Index(prefix, Separator); i >= 0 {
+			return "", "", uuid.Nil, &ParseError{Input: idstr, Part: PartPrefix, Offset: i, Char: rune(Separator[0])}
+		}
+	}
+	if uuidstr == "" {
+		return "", "", uuid.Nil, &ParseError{Input: idstr, Part: PartPayload, Offset: -1, Reason: "is empty"}
+	}
+	for i := 0; i < len(uuidstr); i++ {
+		if base58Index[uuidstr[i]] < 0 {
+			r, _ := utf8.DecodeRuneInString(uuidstr[i:])
+			return "", "", uuid.Nil, &ParseError{Input: idstr, Part: PartPayload, Offset: underscoreIndex + 1 + i, Char: r}
+		}
+	}
+	_uuid, ok := decodeBase58(uuidstr)
+	if !ok {
+		return "", "", uuid.Nil, &ParseError{Input: idstr, Part: PartPayload, Offset: -1, Reason: "does not encode 16 bytes"}
+	}
+	if skew := GetConfig().MaxFutureSkew; skew > 0 && _uuid.Version() == 7 {
+		if v7Time(_uuid).After(time.Now().Add(skew)) {
+			return "", "", uuid.Nil, &ParseError{Input: idstr, Part: PartPayload, Offset: -1, Reason: "has a timestamp too far in the future"}
+		}
+	}
+	return internPrefix(prefix), uuidstr, _uuid, nil
+}
+
+// splitLengthPrefixed returns the prefix of an EncodingLengthPrefixed string
+// and the index of the separator that follows it, or -1 if there is no prefix.
+func splitLengthPrefixed(idstr string) (string, int, error) {
+	markerIndex := strings.Index(idstr, LengthMarker)
+	if markerIndex < 0 {
+		return "", -1, nil
+	}
+	invalid := &ParseError{Input: idstr, Part: PartPrefix, Offset: -1, Reason: "has an invalid length marker"}
+	n, err := strconv.Atoi(idstr[:markerIndex])
+	if err != nil || idstr[0] < '1' || idstr[0] > '9' {
+		return "", -1, invalid
+	}
+	if n > MaxPrefixLen {
+		return "", -1, &ParseError{Input: idstr, Part: PartPrefix, Offset: -1, Reason: "is too long"}
+	}
+	underscoreIndex := markerIndex + len(LengthMarker) + n
+	if underscoreIndex >= len(idstr) || idstr[underscoreIndex:underscoreIndex+len(Separator)] != Separator {
+		return "", -1, invalid
 	}
-	return NewWith(_uuid, prefix)
+	return idstr[markerIndex+len(LengthMarker) : underscoreIndex], underscoreIndex, nil
 }
 
 func IsValid(idstr string) bool {
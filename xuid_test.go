@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/47monad/xuid"
 	"github.com/google/uuid"
@@ -243,14 +244,14 @@ func TestParse(t *testing.T) {
 		_, err := xuid.Parse("invalid_string")
 
 		assert.Error(t, err)
-		assert.Equal(t, xuid.ErrParse, err)
+		assert.ErrorIs(t, err, xuid.ErrParse)
 	})
 
 	t.Run("returns error for malformed base58", func(t *testing.T) {
 		_, err := xuid.Parse("test_invalid0characters")
 
 		assert.Error(t, err)
-		assert.Equal(t, xuid.ErrParse, err)
+		assert.ErrorIs(t, err, xuid.ErrParse)
 	})
 }
 
@@ -388,6 +389,35 @@ func TestVersionChecking(t *testing.T) {
 	})
 }
 
+func TestTime(t *testing.T) {
+	t.Run("returns the embedded creation time", func(t *testing.T) {
+		at := time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.UTC)
+		id, _ := xuid.NewSortableAt("evt", at)
+
+		got, err := id.Time()
+
+		require.NoError(t, err)
+		assert.True(t, at.Truncate(time.Millisecond).Equal(got))
+	})
+
+	t.Run("is close to the creation time of NewSortable", func(t *testing.T) {
+		before := time.Now().Truncate(time.Millisecond)
+		id := xuid.MustNewSortable("evt")
+
+		got, err := id.Time()
+
+		require.NoError(t, err)
+		assert.False(t, got.Before(before))
+		assert.False(t, got.After(time.Now()))
+	})
+
+	t.Run("fails for non-sortable XUIDs", func(t *testing.T) {
+		_, err := xuid.MustNewRandom("evt").Time()
+
+		assert.ErrorIs(t, err, xuid.ErrNotSortable)
+	})
+}
+
 func TestGetters(t *testing.T) {
 	t.Run("GetUUID returns correct UUID", func(t *testing.T) {
 		testUUID := uuid.New()
@@ -425,6 +455,15 @@ func TestSetters(t *testing.T) {
 
 		assert.Equal(t, "new", testXUID.GetPrefix()) // Original unchanged
 	})
+
+	t.Run("SetValidPrefix rejects invalid prefixes", func(t *testing.T) {
+		testXUID, _ := xuid.NewSortable("old")
+
+		err := testXUID.SetValidPrefix(strings.Repeat("a", xuid.MaxPrefixLen+1))
+
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+		assert.Equal(t, "old", testXUID.GetPrefix())
+	})
 }
 
 func TestEdgeCases(t *testing.T) {
@@ -463,6 +502,39 @@ func TestEdgeCases(t *testing.T) {
 	})
 }
 
+func TestParseInto(t *testing.T) {
+	t.Run("parses into destination", func(t *testing.T) {
+		original := xuid.MustNewSortable("user")
+		var dst xuid.XUID
+
+		err := xuid.ParseInto(&dst, original.String())
+
+		require.NoError(t, err)
+		assert.True(t, original.Equal(dst))
+	})
+
+	t.Run("overwrites previous value", func(t *testing.T) {
+		original := xuid.MustNewSortable("")
+		dst := xuid.MustNewSortable("user")
+
+		err := xuid.ParseInto(&dst, original.String())
+
+		require.NoError(t, err)
+		assert.True(t, original.Equal(dst))
+		assert.Equal(t, "", dst.GetPrefix())
+	})
+
+	t.Run("leaves destination unchanged on error", func(t *testing.T) {
+		original := xuid.MustNewSortable("user")
+		dst := original
+
+		err := xuid.ParseInto(&dst, "invalid_string")
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+		assert.True(t, original.Equal(dst))
+	})
+}
+
 func BenchmarkNewSortable(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = xuid.NewSortable("bench")
@@ -494,6 +566,18 @@ func BenchmarkParse(b *testing.B) {
 	}
 }
 
+func BenchmarkParseInto(b *testing.B) {
+	id, _ := xuid.NewSortable("bench")
+	str := id.String()
+	var dst xuid.XUID
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = xuid.ParseInto(&dst, str)
+	}
+}
+
 func BenchmarkJSONMarshal(b *testing.B) {
 	id, _ := xuid.NewSortable("bench")
 	b.ResetTimer()
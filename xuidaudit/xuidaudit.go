@@ -0,0 +1,90 @@
+// Package xuidaudit is a reference audit log record built on XUIDs.
+//
+// Records are identified by sortable XUIDs, which embed their creation
+// time, so audit tables need no separate created_at column: ordering by ID
+// orders by time, and Time reads the timestamp back from the ID.
+package xuidaudit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/47monad/xuid"
+)
+
+// Prefix is the prefix of record IDs.
+const Prefix = "audit"
+
+var ErrInvalidRecord = errors.New("audit record cannot be scanned")
+
+// Record describes an action performed by an actor on an entity.
+type Record struct {
+	ID     xuid.XUID `json:"id"`
+	Actor  xuid.XUID `json:"actor"`
+	Entity xuid.XUID `json:"entity"`
+	Action string    `json:"action"`
+}
+
+// New creates a record with a new sortable ID.
+func New(actor, entity xuid.XUID, action string) (Record, error) {
+	id, err := xuid.NewSortable(Prefix)
+	if err != nil {
+		return Record{}, err
+	}
+	return Record{ID: id, Actor: actor, Entity: entity, Action: action}, nil
+}
+
+// Time returns the creation time embedded in the record ID, or the zero
+// time if the ID is not sortable.
+func (r Record) Time() time.Time {
+	t, err := r.ID.Time()
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+type recordJSON struct {
+	ID     xuid.XUID `json:"id"`
+	Actor  xuid.XUID `json:"actor"`
+	Entity xuid.XUID `json:"entity"`
+	Action string    `json:"action"`
+	Time   time.Time `json:"time"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// It adds the time embedded in the ID to the output for readers that do not
+// decode XUIDs. The time is ignored when unmarshaling.
+func (r Record) MarshalJSON() ([]byte, error) {
+	return json.Marshal(recordJSON{ID: r.ID, Actor: r.Actor, Entity: r.Entity, Action: r.Action, Time: r.Time().UTC()})
+}
+
+// Value implements the driver.Valuer interface.
+// Records are stored as JSON, so that all prefixes are kept, in a jsonb or
+// text column.
+func (r Record) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan implements the sql.Scanner interface.
+func (r *Record) Scan(value interface{}) error {
+	var data []byte
+	switch d := value.(type) {
+	case []byte:
+		data = d
+	case string:
+		data = []byte(d)
+	default:
+		return ErrInvalidRecord
+	}
+	type plain Record
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRecord, err)
+	}
+	*r = Record(p)
+	return nil
+}
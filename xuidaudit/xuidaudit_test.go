@@ -0,0 +1,89 @@
+package xuidaudit_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidaudit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	actor := xuid.MustNewSortable("user")
+	entity := xuid.MustNewSortable("doc")
+
+	before := time.Now().Truncate(time.Millisecond)
+	r, err := xuidaudit.New(actor, entity, "delete")
+	after := time.Now()
+
+	require.NoError(t, err)
+	assert.Equal(t, xuidaudit.Prefix, r.ID.GetPrefix())
+	assert.True(t, r.ID.IsSortable())
+	assert.True(t, actor.Equal(r.Actor))
+	assert.True(t, entity.Equal(r.Entity))
+	assert.Equal(t, "delete", r.Action)
+	assert.False(t, r.Time().Before(before))
+	assert.False(t, r.Time().After(after))
+}
+
+func TestTime(t *testing.T) {
+	at := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	r := xuidaudit.Record{ID: xuid.Must(xuid.NewSortableAt(xuidaudit.Prefix, at))}
+	assert.True(t, at.Equal(r.Time()))
+
+	r = xuidaudit.Record{ID: xuid.MustNewRandom(xuidaudit.Prefix)}
+	assert.True(t, r.Time().IsZero())
+}
+
+func TestJSON(t *testing.T) {
+	at := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	r := xuidaudit.Record{
+		ID:     xuid.Must(xuid.NewSortableAt(xuidaudit.Prefix, at)),
+		Actor:  xuid.MustNewSortable("user"),
+		Entity: xuid.MustNewSortable("doc"),
+		Action: "update",
+	}
+
+	data, err := json.Marshal(r)
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+	assert.Equal(t, "2024-03-01T12:00:00Z", fields["time"])
+
+	var decoded xuidaudit.Record
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, r.ID.Equal(decoded.ID))
+	assert.True(t, r.Actor.Equal(decoded.Actor))
+	assert.Equal(t, "doc", decoded.Entity.GetPrefix())
+}
+
+func TestSQL(t *testing.T) {
+	r, err := xuidaudit.New(xuid.MustNewSortable("user"), xuid.MustNewSortable("doc"), "read")
+	require.NoError(t, err)
+
+	t.Run("round-trips through Value and Scan", func(t *testing.T) {
+		v, err := r.Value()
+		require.NoError(t, err)
+
+		for _, stored := range []any{v, string(v.([]byte))} {
+			var loaded xuidaudit.Record
+			require.NoError(t, loaded.Scan(stored))
+
+			assert.True(t, r.ID.Equal(loaded.ID))
+			assert.True(t, r.Actor.Equal(loaded.Actor))
+			assert.True(t, r.Entity.Equal(loaded.Entity))
+			assert.Equal(t, r.Action, loaded.Action)
+		}
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		var loaded xuidaudit.Record
+
+		assert.ErrorIs(t, loaded.Scan(42), xuidaudit.ErrInvalidRecord)
+		assert.ErrorIs(t, loaded.Scan("{"), xuidaudit.ErrInvalidRecord)
+	})
+}
@@ -0,0 +1,72 @@
+// Package xuidbigquery helps store XUIDs, prefix included, in BigQuery
+// STRING columns.
+//
+// BigQuery infers struct fields of type xuid.XUID as records, so rows with
+// XUID fields implement bigquery.ValueSaver and bigquery.ValueLoader with
+// the helpers of this package:
+//
+//	func (u *User) Save() (map[string]bigquery.Value, string, error) {
+//		return map[string]bigquery.Value{"id": xuidbigquery.Value(u.ID), "name": u.Name}, "", nil
+//	}
+//
+//	func (u *User) Load(row []bigquery.Value, schema bigquery.Schema) error {
+//		i := xuidbigquery.Index(schema, "id")
+//		id, err := xuidbigquery.Parse(row[i])
+//		...
+//	}
+package xuidbigquery
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/47monad/xuid"
+)
+
+var ErrInvalidValue = errors.New("BigQuery value is not a XUID string")
+
+// Value returns the BigQuery value of x: its string form, or NULL for the
+// nil UUID.
+func Value(x xuid.XUID) bigquery.Value {
+	if xuid.IsEmpty(x) {
+		return nil
+	}
+	return x.String()
+}
+
+// Parse is the inverse of Value. NULL yields the nil XUID.
+func Parse(v bigquery.Value) (xuid.XUID, error) {
+	switch s := v.(type) {
+	case nil:
+		return xuid.NilUUID()
+	case string:
+		x, err := xuid.Parse(s)
+		if err != nil {
+			return xuid.XUID{}, fmt.Errorf("%w: %w", ErrInvalidValue, err)
+		}
+		return x, nil
+	}
+	return xuid.XUID{}, fmt.Errorf("%w: unsupported type %T", ErrInvalidValue, v)
+}
+
+// FieldSchema returns the schema of a STRING column named name holding
+// XUIDs. Its maximum length is xuid.MaxStringLen.
+func FieldSchema(name string, required bool) *bigquery.FieldSchema {
+	return &bigquery.FieldSchema{
+		Name:      name,
+		Type:      bigquery.StringFieldType,
+		Required:  required,
+		MaxLength: int64(xuid.MaxStringLen),
+	}
+}
+
+// Index returns the position of the column named name in schema, or -1.
+func Index(schema bigquery.Schema, name string) int {
+	for i, f := range schema {
+		if f.Name == name {
+			return i
+		}
+	}
+	return -1
+}
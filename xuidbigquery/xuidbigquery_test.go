@@ -0,0 +1,115 @@
+package xuidbigquery_test
+
+import (
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidbigquery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type user struct {
+	ID   xuid.XUID
+	Name string
+}
+
+func (u *user) Save() (map[string]bigquery.Value, string, error) {
+	return map[string]bigquery.Value{"id": xuidbigquery.Value(u.ID), "name": u.Name}, "", nil
+}
+
+func (u *user) Load(row []bigquery.Value, schema bigquery.Schema) error {
+	id, err := xuidbigquery.Parse(row[xuidbigquery.Index(schema, "id")])
+	if err != nil {
+		return err
+	}
+	name, _ := row[xuidbigquery.Index(schema, "name")].(string)
+	*u = user{ID: id, Name: name}
+	return nil
+}
+
+var (
+	_ bigquery.ValueSaver  = (*user)(nil)
+	_ bigquery.ValueLoader = (*user)(nil)
+)
+
+func TestValue(t *testing.T) {
+	t.Run("stores string form with prefix", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+
+		assert.Equal(t, x.String(), xuidbigquery.Value(x))
+	})
+
+	t.Run("stores nil UUID as NULL", func(t *testing.T) {
+		x, _ := xuid.NilUUID()
+
+		assert.Nil(t, xuidbigquery.Value(x))
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("parses strings", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+
+		parsed, err := xuidbigquery.Parse(x.String())
+
+		require.NoError(t, err)
+		assert.True(t, x.Equal(parsed))
+		assert.Equal(t, "user", parsed.GetPrefix())
+	})
+
+	t.Run("parses NULL as nil XUID", func(t *testing.T) {
+		parsed, err := xuidbigquery.Parse(nil)
+
+		require.NoError(t, err)
+		assert.True(t, xuid.IsEmpty(parsed))
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		_, err := xuidbigquery.Parse("user_0OIl")
+		assert.ErrorIs(t, err, xuidbigquery.ErrInvalidValue)
+		assert.ErrorIs(t, err, xuid.ErrParse)
+
+		_, err = xuidbigquery.Parse(int64(1))
+		assert.True(t, errors.Is(err, xuidbigquery.ErrInvalidValue))
+	})
+}
+
+func TestFieldSchema(t *testing.T) {
+	f := xuidbigquery.FieldSchema("id", true)
+
+	assert.Equal(t, "id", f.Name)
+	assert.Equal(t, bigquery.StringFieldType, f.Type)
+	assert.True(t, f.Required)
+	assert.Equal(t, int64(xuid.MaxStringLen), f.MaxLength)
+}
+
+func TestSaveLoad(t *testing.T) {
+	schema := bigquery.Schema{
+		xuidbigquery.FieldSchema("id", true),
+		{Name: "name", Type: bigquery.StringFieldType},
+	}
+	original := user{ID: xuid.MustNewSortable("user"), Name: "Ada"}
+
+	row, _, err := original.Save()
+	require.NoError(t, err)
+	values := make([]bigquery.Value, len(schema))
+	for i, f := range schema {
+		values[i] = row[f.Name]
+	}
+	var loaded user
+	require.NoError(t, loaded.Load(values, schema))
+
+	assert.True(t, original.ID.Equal(loaded.ID))
+	assert.Equal(t, "user", loaded.ID.GetPrefix())
+	assert.Equal(t, original.Name, loaded.Name)
+}
+
+func TestIndex(t *testing.T) {
+	schema := bigquery.Schema{{Name: "a"}, {Name: "id"}}
+
+	assert.Equal(t, 1, xuidbigquery.Index(schema, "id"))
+	assert.Equal(t, -1, xuidbigquery.Index(schema, "missing"))
+}
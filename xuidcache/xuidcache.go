@@ -0,0 +1,83 @@
+// Package xuidcache provides an LRU cache keyed by XUIDs.
+//
+// Keys are the XUID values themselves: lookups hash the UUID bytes and the
+// prefix, without converting keys to strings.
+package xuidcache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/47monad/xuid"
+)
+
+// LRU is a fixed-size cache that evicts the least recently used entry.
+// It is safe for concurrent use.
+type LRU[V any] struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[xuid.XUID]*list.Element
+	order    *list.List
+}
+
+type entry[V any] struct {
+	key   xuid.XUID
+	value V
+}
+
+// New returns an LRU holding at most capacity entries. Capacities below 1
+// are treated as 1.
+func New[V any](capacity int) *LRU[V] {
+	capacity = max(capacity, 1)
+	return &LRU[V]{capacity: capacity, items: make(map[xuid.XUID]*list.Element, capacity), order: list.New()}
+}
+
+// Get returns the value cached for x and marks it as recently used.
+func (c *LRU[V]) Get(x xuid.XUID) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[x]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*entry[V]).value, true
+}
+
+// Add caches v for x, and reports whether an entry was evicted to make
+// room for it.
+func (c *LRU[V]) Add(x xuid.XUID, v V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[x]; ok {
+		el.Value.(*entry[V]).value = v
+		c.order.MoveToFront(el)
+		return false
+	}
+	c.items[x] = c.order.PushFront(&entry[V]{key: x, value: v})
+	if c.order.Len() <= c.capacity {
+		return false
+	}
+	oldest := c.order.Back()
+	c.order.Remove(oldest)
+	delete(c.items, oldest.Value.(*entry[V]).key)
+	return true
+}
+
+// Remove removes x from the cache.
+func (c *LRU[V]) Remove(x xuid.XUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[x]; ok {
+		c.order.Remove(el)
+		delete(c.items, x)
+	}
+}
+
+// Len returns the number of cached entries.
+func (c *LRU[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
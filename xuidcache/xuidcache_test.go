@@ -0,0 +1,83 @@
+package xuidcache_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidcache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU(t *testing.T) {
+	t.Run("caches values", func(t *testing.T) {
+		c := xuidcache.New[string](2)
+		x := xuid.MustNewSortable("user")
+
+		assert.False(t, c.Add(x, "alice"))
+
+		v, ok := c.Get(x)
+		assert.True(t, ok)
+		assert.Equal(t, "alice", v)
+		assert.Equal(t, 1, c.Len())
+	})
+
+	t.Run("evicts least recently used entry", func(t *testing.T) {
+		c := xuidcache.New[int](2)
+		a, b, d := xuid.MustNewSortable("user"), xuid.MustNewSortable("user"), xuid.MustNewSortable("user")
+		c.Add(a, 1)
+		c.Add(b, 2)
+		c.Get(a)
+
+		assert.True(t, c.Add(d, 3))
+
+		_, ok := c.Get(b)
+		assert.False(t, ok)
+		_, ok = c.Get(a)
+		assert.True(t, ok)
+		assert.Equal(t, 2, c.Len())
+	})
+
+	t.Run("replaces values without evicting", func(t *testing.T) {
+		c := xuidcache.New[int](1)
+		x := xuid.MustNewSortable("user")
+		c.Add(x, 1)
+
+		assert.False(t, c.Add(x, 2))
+
+		v, _ := c.Get(x)
+		assert.Equal(t, 2, v)
+	})
+
+	t.Run("distinguishes prefixes", func(t *testing.T) {
+		c := xuidcache.New[int](2)
+		x := xuid.MustNewSortable("user")
+		y, _ := xuid.NewWith(x.GetUUID(), "member")
+		c.Add(x, 1)
+
+		_, ok := c.Get(y)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("removes entries", func(t *testing.T) {
+		c := xuidcache.New[int](2)
+		x := xuid.MustNewSortable("user")
+		c.Add(x, 1)
+
+		c.Remove(x)
+
+		_, ok := c.Get(x)
+		assert.False(t, ok)
+		assert.Equal(t, 0, c.Len())
+	})
+}
+
+func BenchmarkLRUGet(b *testing.B) {
+	c := xuidcache.New[int](1024)
+	x := xuid.MustNewSortable("user")
+	c.Add(x, 1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.Get(x)
+	}
+}
@@ -0,0 +1,117 @@
+package xuidcollections
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+)
+
+// IntervalTree indexes values by ranges of XUIDs and answers stabbing
+// queries, which return the values whose range contains a given XUID, in
+// O(log n + k) time for k results. Retention and archival jobs can use it
+// to find the policies covering an event ID, with ranges built by
+// xuid.TimeRange.
+//
+// Intervals are kept sorted by start in an implicit balanced tree whose
+// nodes record the largest end of their subtree. The tree is rebuilt by
+// the first query after an insertion, so bulk loading costs O(n log n).
+//
+// An IntervalTree is not safe for concurrent use. The zero value is an
+// empty tree.
+type IntervalTree[V any] struct {
+	items  []interval[V]
+	maxEnd []uuid.UUID
+	dirty  bool
+}
+
+type interval[V any] struct {
+	r xuid.Range
+	v V
+}
+
+// Insert adds v for the range r. Empty ranges are never returned by Stab.
+func (t *IntervalTree[V]) Insert(r xuid.Range, v V) {
+	t.items = append(t.items, interval[V]{r, v})
+	t.dirty = true
+}
+
+// Len returns the number of intervals in t.
+func (t *IntervalTree[V]) Len() int {
+	return len(t.items)
+}
+
+// Stab calls f for each interval containing x, in order of range start,
+// until f returns false.
+func (t *IntervalTree[V]) Stab(x xuid.XUID, f func(r xuid.Range, v V) bool) {
+	if t.dirty {
+		t.build()
+	}
+	id := x.GetUUID()
+	t.stab(0, len(t.items), id[:], f)
+}
+
+// StabValues returns the values of the intervals containing x, in order of
+// range start.
+func (t *IntervalTree[V]) StabValues(x xuid.XUID) []V {
+	var res []V
+	t.Stab(x, func(_ xuid.Range, v V) bool {
+		res = append(res, v)
+		return true
+	})
+	return res
+}
+
+// stab visits the subtree of items[lo:hi], rooted at its middle, and
+// reports whether to continue.
+func (t *IntervalTree[V]) stab(lo, hi int, id []byte, f func(xuid.Range, V) bool) bool {
+	if lo >= hi {
+		return true
+	}
+	mid := (lo + hi) / 2
+	if bytes.Compare(t.maxEnd[mid][:], id) < 0 {
+		return true
+	}
+	if !t.stab(lo, mid, id, f) {
+		return false
+	}
+	it := t.items[mid]
+	if bytes.Compare(it.r.Start[:], id) > 0 {
+		return true
+	}
+	if bytes.Compare(id, it.r.End[:]) <= 0 && !f(it.r, it.v) {
+		return false
+	}
+	return t.stab(mid+1, hi, id, f)
+}
+
+func (t *IntervalTree[V]) build() {
+	slices.SortStableFunc(t.items, func(a, b interval[V]) int {
+		return bytes.Compare(a.r.Start[:], b.r.Start[:])
+	})
+	t.maxEnd = make([]uuid.UUID, len(t.items))
+	if len(t.items) > 0 {
+		t.buildMax(0, len(t.items))
+	}
+	t.dirty = false
+}
+
+// buildMax records the largest end of the subtree of items[lo:hi] at its
+// root and returns it.
+func (t *IntervalTree[V]) buildMax(lo, hi int) uuid.UUID {
+	mid := (lo + hi) / 2
+	m := t.items[mid].r.End
+	if lo < mid {
+		if l := t.buildMax(lo, mid); bytes.Compare(l[:], m[:]) > 0 {
+			m = l
+		}
+	}
+	if mid+1 < hi {
+		if r := t.buildMax(mid+1, hi); bytes.Compare(r[:], m[:]) > 0 {
+			m = r
+		}
+	}
+	t.maxEnd[mid] = m
+	return m
+}
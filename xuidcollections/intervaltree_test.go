@@ -0,0 +1,75 @@
+package xuidcollections_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidcollections"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalTree(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, 1+d, 0, 0, 0, 0, time.UTC) }
+	event := func(d int) xuid.XUID { return xuid.Must(xuid.NewSortableAt("evt", day(d).Add(time.Hour))) }
+
+	t.Run("returns the intervals containing a XUID", func(t *testing.T) {
+		var tree xuidcollections.IntervalTree[string]
+		tree.Insert(xuid.TimeRange(day(0), day(30)), "30d")
+		tree.Insert(xuid.TimeRange(day(0), day(7)), "7d")
+		tree.Insert(xuid.TimeRange(day(10), day(20)), "legal-hold")
+
+		assert.Equal(t, []string{"30d", "7d"}, tree.StabValues(event(3)))
+		assert.Equal(t, []string{"30d", "legal-hold"}, tree.StabValues(event(15)))
+		assert.Empty(t, tree.StabValues(event(40)))
+		assert.Equal(t, 3, tree.Len())
+	})
+
+	t.Run("handles empty trees and insertions after queries", func(t *testing.T) {
+		var tree xuidcollections.IntervalTree[int]
+		assert.Empty(t, tree.StabValues(event(1)))
+
+		tree.Insert(xuid.TimeRange(day(0), day(2)), 1)
+
+		assert.Equal(t, []int{1}, tree.StabValues(event(1)))
+	})
+
+	t.Run("stops when f returns false", func(t *testing.T) {
+		var tree xuidcollections.IntervalTree[int]
+		for i := 0; i < 5; i++ {
+			tree.Insert(xuid.TimeRange(day(0), day(10)), i)
+		}
+
+		n := 0
+		tree.Stab(event(1), func(xuid.Range, int) bool {
+			n++
+			return n < 2
+		})
+
+		assert.Equal(t, 2, n)
+	})
+
+	t.Run("matches a linear scan", func(t *testing.T) {
+		rng := rand.New(rand.NewPCG(1, 2))
+		var tree xuidcollections.IntervalTree[int]
+		var ranges []xuid.Range
+		for i := 0; i < 200; i++ {
+			start := rng.IntN(100)
+			r := xuid.TimeRange(day(start), day(start+rng.IntN(20)))
+			ranges = append(ranges, r)
+			tree.Insert(r, i)
+		}
+
+		for d := 0; d < 120; d++ {
+			x := event(d)
+			var want []int
+			for i, r := range ranges {
+				if r.Contains(x) {
+					want = append(want, i)
+				}
+			}
+			assert.ElementsMatch(t, want, tree.StabValues(x), d)
+		}
+	})
+}
@@ -0,0 +1,86 @@
+// Package xuidcollections provides containers keyed by XUIDs.
+package xuidcollections
+
+import (
+	"slices"
+
+	"github.com/47monad/xuid"
+)
+
+// OrderedMap maps XUIDs to values of type V and iterates over them in the
+// order defined by xuid.Compare, which is chronological for sortable
+// XUIDs, without sorting keys on every pass. Keys are kept in a sorted
+// slice next to the map: adding keys in increasing order, as when
+// consuming an event stream, appends to it, while out-of-order keys and
+// deletions move later keys.
+//
+// An OrderedMap is not safe for concurrent use. The zero value is not
+// usable; create maps with NewOrderedMap.
+type OrderedMap[V any] struct {
+	values map[xuid.XUID]V
+	keys   []xuid.XUID
+}
+
+// NewOrderedMap returns an empty map.
+func NewOrderedMap[V any]() *OrderedMap[V] {
+	return &OrderedMap[V]{values: map[xuid.XUID]V{}}
+}
+
+// Set associates v with x, replacing any previous value.
+func (m *OrderedMap[V]) Set(x xuid.XUID, v V) {
+	if _, ok := m.values[x]; !ok {
+		if n := len(m.keys); n == 0 || xuid.Compare(m.keys[n-1], x) < 0 {
+			m.keys = append(m.keys, x)
+		} else {
+			m.keys = xuid.InsertSorted(m.keys, x)
+		}
+	}
+	m.values[x] = v
+}
+
+// Get returns the value associated with x.
+func (m *OrderedMap[V]) Get(x xuid.XUID) (V, bool) {
+	v, ok := m.values[x]
+	return v, ok
+}
+
+// Delete removes x from the map.
+func (m *OrderedMap[V]) Delete(x xuid.XUID) {
+	if _, ok := m.values[x]; !ok {
+		return
+	}
+	delete(m.values, x)
+	i, _ := xuid.SearchSorted(m.keys, x)
+	m.keys = slices.Delete(m.keys, i, i+1)
+}
+
+// Len returns the number of XUIDs in the map.
+func (m *OrderedMap[V]) Len() int {
+	return len(m.keys)
+}
+
+// Keys returns the XUIDs of the map in order.
+func (m *OrderedMap[V]) Keys() []xuid.XUID {
+	return slices.Clone(m.keys)
+}
+
+// Range calls f for each entry in order, until f returns false. f must not
+// modify the map.
+func (m *OrderedMap[V]) Range(f func(x xuid.XUID, v V) bool) {
+	m.rangeFrom(0, f)
+}
+
+// RangeFrom is like Range but starts at the first XUID that does not sort
+// before from, which lets replays resume after a checkpoint.
+func (m *OrderedMap[V]) RangeFrom(from xuid.XUID, f func(x xuid.XUID, v V) bool) {
+	i, _ := xuid.SearchSorted(m.keys, from)
+	m.rangeFrom(i, f)
+}
+
+func (m *OrderedMap[V]) rangeFrom(i int, f func(x xuid.XUID, v V) bool) {
+	for _, x := range m.keys[i:] {
+		if !f(x, m.values[x]) {
+			return
+		}
+	}
+}
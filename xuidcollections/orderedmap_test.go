@@ -0,0 +1,89 @@
+package xuidcollections_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidcollections"
+	"github.com/stretchr/testify/assert"
+)
+
+// events returns n sortable XUIDs one second apart, in order.
+func events(n int) []xuid.XUID {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]xuid.XUID, n)
+	for i := range ids {
+		ids[i] = xuid.Must(xuid.NewSortableAt("evt", start.Add(time.Duration(i)*time.Second)))
+	}
+	return ids
+}
+
+func collect[V any](m *xuidcollections.OrderedMap[V]) ([]xuid.XUID, []V) {
+	var keys []xuid.XUID
+	var values []V
+	m.Range(func(x xuid.XUID, v V) bool {
+		keys, values = append(keys, x), append(values, v)
+		return true
+	})
+	return keys, values
+}
+
+func TestOrderedMap(t *testing.T) {
+	ids := events(5)
+
+	t.Run("iterates in chronological order", func(t *testing.T) {
+		m := xuidcollections.NewOrderedMap[int]()
+		for _, i := range []int{3, 0, 4, 1, 2} {
+			m.Set(ids[i], i)
+		}
+
+		keys, values := collect(m)
+
+		assert.Equal(t, ids, keys)
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, values)
+		assert.Equal(t, ids, m.Keys())
+	})
+
+	t.Run("replaces values", func(t *testing.T) {
+		m := xuidcollections.NewOrderedMap[string]()
+		m.Set(ids[0], "a")
+		m.Set(ids[0], "b")
+
+		v, ok := m.Get(ids[0])
+
+		assert.True(t, ok)
+		assert.Equal(t, "b", v)
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("deletes entries", func(t *testing.T) {
+		m := xuidcollections.NewOrderedMap[int]()
+		for i, x := range ids {
+			m.Set(x, i)
+		}
+
+		m.Delete(ids[2])
+		m.Delete(ids[2])
+
+		_, ok := m.Get(ids[2])
+		assert.False(t, ok)
+		keys, _ := collect(m)
+		assert.Equal(t, []xuid.XUID{ids[0], ids[1], ids[3], ids[4]}, keys)
+	})
+
+	t.Run("ranges from a checkpoint", func(t *testing.T) {
+		m := xuidcollections.NewOrderedMap[int]()
+		for i, x := range ids {
+			m.Set(x, i)
+		}
+
+		var got []int
+		m.RangeFrom(ids[2], func(_ xuid.XUID, v int) bool {
+			got = append(got, v)
+			return len(got) < 2
+		})
+
+		assert.Equal(t, []int{2, 3}, got)
+	})
+}
@@ -0,0 +1,83 @@
+package xuidconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/47monad/xuid"
+)
+
+// ErrInvalidDest is xuid.ErrInvalidDest, returned by BindEnv for
+// destinations other than pointers to structs.
+var ErrInvalidDest = xuid.ErrInvalidDest
+
+// BindEnv sets the XUID fields of the struct dest points to from the
+// environment variables named by their env tags, using xuid.FromEnv:
+//
+//	type Config struct {
+//		TenantID xuid.XUID  `mapstructure:"tenant_id" env:"TENANT_ID,required"`
+//		SeedID   *xuid.XUID `mapstructure:"seed_id" env:"SEED_ID"`
+//	}
+//
+// Fields whose variable is unset keep their value, so calling BindEnv after
+// viper.Unmarshal lets the environment override configuration files. The
+// required option fails with xuid.ErrMissingXUID when the variable is unset
+// and the field is still nil. Fields of nested structs are included.
+func BindEnv(dest any) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: want a pointer to a struct, got %T", ErrInvalidDest, dest)
+	}
+	return bindEnv(v.Elem())
+}
+
+func bindEnv(v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		sf := v.Type().Field(i)
+		// As with encoding/json, the exported fields of unexported embedded
+		// structs are bound too.
+		if !sf.IsExported() && !sf.Anonymous {
+			continue
+		}
+		fv := v.Field(i)
+		tag, ok := sf.Tag.Lookup("env")
+		switch {
+		case ok && sf.IsExported() && (sf.Type == xuidType || sf.Type == reflect.PointerTo(xuidType)):
+			if err := bindField(fv, tag); err != nil {
+				return fmt.Errorf("field %s: %w", sf.Name, err)
+			}
+		case sf.Type.Kind() == reflect.Struct && sf.Type != xuidType:
+			if err := bindEnv(fv); err != nil {
+				return err
+			}
+		case sf.Type.Kind() == reflect.Pointer && sf.Type.Elem().Kind() == reflect.Struct && sf.Type.Elem() != xuidType && !fv.IsNil():
+			if err := bindEnv(fv.Elem()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func bindField(fv reflect.Value, tag string) error {
+	key, opts, _ := strings.Cut(tag, ",")
+	x, err := xuid.FromEnv(key, false)
+	if err != nil {
+		return err
+	}
+	ptr := fv.Kind() == reflect.Pointer
+	if xuid.IsEmpty(x) {
+		unset := ptr && fv.IsNil() || !ptr && xuid.IsEmpty(fv.Interface().(xuid.XUID))
+		if opts == "required" && unset {
+			return fmt.Errorf("%w: environment variable %s is not set", xuid.ErrMissingXUID, key)
+		}
+		return nil
+	}
+	if ptr {
+		fv.Set(reflect.ValueOf(&x))
+	} else {
+		fv.Set(reflect.ValueOf(x))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package xuidconfig_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type envConfig struct {
+	TenantID xuid.XUID  `env:"XUID_TEST_TENANT_ID,required"`
+	SeedID   *xuid.XUID `env:"XUID_TEST_SEED_ID"`
+	Nested   struct {
+		OwnerID xuid.XUID `env:"XUID_TEST_OWNER_ID"`
+	}
+}
+
+func TestBindEnv(t *testing.T) {
+	tenant, seed, owner := xuid.MustNewSortable("tenant"), xuid.MustNewSortable("seed"), xuid.MustNewSortable("user")
+
+	t.Run("sets tagged fields", func(t *testing.T) {
+		t.Setenv("XUID_TEST_TENANT_ID", tenant.String())
+		t.Setenv("XUID_TEST_SEED_ID", seed.String())
+		t.Setenv("XUID_TEST_OWNER_ID", owner.String())
+		var cfg envConfig
+
+		require.NoError(t, xuidconfig.BindEnv(&cfg))
+
+		assert.Equal(t, tenant, cfg.TenantID)
+		assert.Equal(t, seed, *cfg.SeedID)
+		assert.Equal(t, owner, cfg.Nested.OwnerID)
+	})
+
+	t.Run("sets fields of unexported embedded structs", func(t *testing.T) {
+		t.Setenv("XUID_TEST_OWNER_ID", owner.String())
+		type owned struct {
+			OwnerID xuid.XUID `env:"XUID_TEST_OWNER_ID"`
+		}
+		var cfg struct{ owned }
+
+		require.NoError(t, xuidconfig.BindEnv(&cfg))
+
+		assert.Equal(t, owner, cfg.OwnerID)
+	})
+
+	t.Run("keeps values of unset variables", func(t *testing.T) {
+		cfg := envConfig{TenantID: tenant}
+
+		require.NoError(t, xuidconfig.BindEnv(&cfg))
+
+		assert.Equal(t, tenant, cfg.TenantID)
+		assert.Nil(t, cfg.SeedID)
+	})
+
+	t.Run("rejects missing required values", func(t *testing.T) {
+		var cfg envConfig
+
+		err := xuidconfig.BindEnv(&cfg)
+
+		assert.ErrorIs(t, err, xuid.ErrMissingXUID)
+		assert.Contains(t, err.Error(), "TenantID")
+	})
+
+	t.Run("reports invalid values", func(t *testing.T) {
+		t.Setenv("XUID_TEST_SEED_ID", "seed_0OIl")
+		cfg := envConfig{TenantID: tenant}
+
+		assert.ErrorIs(t, xuidconfig.BindEnv(&cfg), xuid.ErrParse)
+	})
+
+	t.Run("rejects invalid destinations", func(t *testing.T) {
+		assert.ErrorIs(t, xuidconfig.BindEnv(envConfig{}), xuidconfig.ErrInvalidDest)
+	})
+}
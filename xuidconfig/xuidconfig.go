@@ -0,0 +1,33 @@
+// Package xuidconfig decodes XUIDs in configuration loaded with viper or
+// mapstructure, such as allow-lists and seed IDs, so invalid IDs are
+// reported when the configuration is loaded rather than when first used.
+package xuidconfig
+
+import (
+	"reflect"
+
+	"github.com/47monad/xuid"
+)
+
+var xuidType = reflect.TypeOf(xuid.XUID{})
+
+// DecodeHook returns a mapstructure decode hook that parses strings into
+// XUID fields, including pointers, slices and map values of XUIDs. Its
+// type is assignable to mapstructure.DecodeHookFuncType, so it can be
+// passed to viper without this package depending on mapstructure:
+//
+//	err := viper.Unmarshal(&cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+//		xuidconfig.DecodeHook(),
+//		mapstructure.StringToTimeDurationHookFunc(),
+//	)))
+//
+// Strings that are not valid XUIDs fail with the parse error, which
+// mapstructure reports along with the name of the field.
+func DecodeHook() func(from, to reflect.Type, data any) (any, error) {
+	return func(from, to reflect.Type, data any) (any, error) {
+		if to != xuidType || from.Kind() != reflect.String {
+			return data, nil
+		}
+		return xuid.Parse(reflect.ValueOf(data).String())
+	}
+}
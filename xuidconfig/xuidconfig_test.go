@@ -0,0 +1,44 @@
+package xuidconfig_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeHookFuncType is the type of mapstructure.DecodeHookFuncType.
+type decodeHookFuncType func(reflect.Type, reflect.Type, interface{}) (interface{}, error)
+
+func TestDecodeHook(t *testing.T) {
+	var hook decodeHookFuncType = xuidconfig.DecodeHook()
+	xuidType := reflect.TypeOf(xuid.XUID{})
+
+	t.Run("parses strings into XUIDs", func(t *testing.T) {
+		id := xuid.MustNewSortable("tenant")
+
+		v, err := hook(reflect.TypeOf(""), xuidType, id.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, id, v)
+	})
+
+	t.Run("reports invalid XUIDs", func(t *testing.T) {
+		_, err := hook(reflect.TypeOf(""), xuidType, "tenant_0OIl")
+
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+
+	t.Run("passes other data through", func(t *testing.T) {
+		v, err := hook(reflect.TypeOf(""), reflect.TypeOf(""), "tenant_0OIl")
+		require.NoError(t, err)
+		assert.Equal(t, "tenant_0OIl", v)
+
+		v, err = hook(reflect.TypeOf(1), xuidType, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, v)
+	})
+}
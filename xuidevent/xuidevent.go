@@ -0,0 +1,85 @@
+// Package xuidevent provides event ID conventions for transactional outbox
+// implementations.
+//
+// Event IDs are sortable XUIDs whose prefix is derived from the prefix of
+// the aggregate emitting them, so the event log sorts chronologically and
+// every event can be traced back to its aggregate type. Deduplication keys
+// are derived from the aggregate ID and the event's sequence number, so
+// consumers can drop redeliveries of the same event even when it was
+// re-published with a new event ID.
+package xuidevent
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/47monad/xuid"
+)
+
+// PrefixSuffix is appended to aggregate prefixes to form event prefixes.
+const PrefixSuffix = "-evt"
+
+// Prefix returns the prefix of events emitted by aggregates with the given
+// prefix, for example "order-evt" for "order".
+func Prefix(aggregatePrefix string) string {
+	return aggregatePrefix + PrefixSuffix
+}
+
+// Source creates event IDs for one aggregate type.
+type Source struct {
+	prefix string
+}
+
+// NewSource returns a Source for aggregates with the given prefix.
+func NewSource(aggregatePrefix string) Source {
+	return Source{prefix: Prefix(aggregatePrefix)}
+}
+
+// NewEventID creates a sortable event ID.
+func (s Source) NewEventID() (xuid.XUID, error) {
+	return xuid.NewSortable(s.prefix)
+}
+
+func (s Source) MustNewEventID() xuid.XUID {
+	return xuid.Must(s.NewEventID())
+}
+
+// DedupKey derives the deduplication key of the event with sequence number
+// seq emitted by aggregate. The key is a UUIDv8 made of a SHA-256 hash of
+// both, and has the event prefix of the aggregate. It fails with
+// xuid.ErrPrefixTooLong if the event prefix exceeds xuid.MaxPrefixLen.
+func DedupKey(aggregate xuid.XUID, seq uint64) (xuid.XUID, error) {
+	u := aggregate.GetUUID()
+	h := sha256.New()
+	h.Write([]byte(aggregate.GetPrefix()))
+	h.Write([]byte{0})
+	h.Write(u[:])
+	binary.Write(h, binary.BigEndian, seq)
+	return xuid.NewFromHash(h.Sum(nil), Prefix(aggregate.GetPrefix()))
+}
+
+// Envelope wraps an event payload for storage in an outbox table and
+// publication to a broker.
+type Envelope struct {
+	ID          xuid.XUID       `json:"id"`
+	AggregateID xuid.XUID       `json:"aggregate_id"`
+	Sequence    uint64          `json:"sequence"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+}
+
+// NewEnvelope creates the envelope of the event with sequence number seq
+// emitted by aggregate.
+func NewEnvelope(aggregate xuid.XUID, seq uint64, typ string, payload json.RawMessage) (Envelope, error) {
+	id, err := xuid.NewSortable(Prefix(aggregate.GetPrefix()))
+	if err != nil {
+		return Envelope{}, err
+	}
+	return Envelope{ID: id, AggregateID: aggregate, Sequence: seq, Type: typ, Payload: payload}, nil
+}
+
+// DedupKey returns the deduplication key of the event.
+func (e Envelope) DedupKey() (xuid.XUID, error) {
+	return DedupKey(e.AggregateID, e.Sequence)
+}
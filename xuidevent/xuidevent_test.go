@@ -0,0 +1,104 @@
+package xuidevent_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidevent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource(t *testing.T) {
+	t.Run("creates sortable event IDs with event prefix", func(t *testing.T) {
+		src := xuidevent.NewSource("order")
+
+		id1 := src.MustNewEventID()
+		id2, err := src.NewEventID()
+
+		require.NoError(t, err)
+		assert.True(t, id1.IsSortable())
+		assert.Equal(t, "order-evt", id1.GetPrefix())
+		assert.Equal(t, -1, xuid.Compare(id1, id2))
+	})
+}
+
+// dedupKey returns the deduplication key of the event, failing the test on
+// error.
+func dedupKey(t *testing.T, aggregate xuid.XUID, seq uint64) xuid.XUID {
+	t.Helper()
+	key, err := xuidevent.DedupKey(aggregate, seq)
+	require.NoError(t, err)
+	return key
+}
+
+func TestDedupKey(t *testing.T) {
+	order := xuid.MustNewSortable("order")
+
+	t.Run("is deterministic", func(t *testing.T) {
+		assert.True(t, dedupKey(t, order, 3).Equal(dedupKey(t, order, 3)))
+	})
+
+	t.Run("differs per sequence and aggregate", func(t *testing.T) {
+		other := xuid.MustNewSortable("order")
+
+		assert.False(t, dedupKey(t, order, 3).Equal(dedupKey(t, order, 4)))
+		assert.False(t, dedupKey(t, order, 3).Equal(dedupKey(t, other, 3)))
+	})
+
+	t.Run("has event prefix", func(t *testing.T) {
+		assert.Equal(t, "order-evt", dedupKey(t, order, 1).GetPrefix())
+	})
+
+	t.Run("fails when the event prefix is too long", func(t *testing.T) {
+		long := xuid.MustNewSortable(strings.Repeat("a", xuid.MaxPrefixLen))
+
+		_, err := xuidevent.DedupKey(long, 1)
+
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+	})
+}
+
+func TestEnvelope(t *testing.T) {
+	order := xuid.MustNewSortable("order")
+
+	t.Run("creates envelope with event ID", func(t *testing.T) {
+		env, err := xuidevent.NewEnvelope(order, 7, "order.placed", json.RawMessage(`{"total":10}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, "order-evt", env.ID.GetPrefix())
+		assert.True(t, env.ID.IsSortable())
+		key, err := env.DedupKey()
+		require.NoError(t, err)
+		assert.True(t, dedupKey(t, order, 7).Equal(key))
+	})
+
+	t.Run("keeps dedup key across re-publication", func(t *testing.T) {
+		env1, _ := xuidevent.NewEnvelope(order, 7, "order.placed", nil)
+		env2, _ := xuidevent.NewEnvelope(order, 7, "order.placed", nil)
+
+		key1, err1 := env1.DedupKey()
+		key2, err2 := env2.DedupKey()
+
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.False(t, env1.ID.Equal(env2.ID))
+		assert.True(t, key1.Equal(key2))
+	})
+
+	t.Run("round trips through JSON", func(t *testing.T) {
+		env, _ := xuidevent.NewEnvelope(order, 7, "order.placed", json.RawMessage(`{"total":10}`))
+
+		data, err := json.Marshal(env)
+		require.NoError(t, err)
+		var parsed xuidevent.Envelope
+		require.NoError(t, json.Unmarshal(data, &parsed))
+
+		assert.True(t, env.ID.Equal(parsed.ID))
+		assert.True(t, order.Equal(parsed.AggregateID))
+		assert.Equal(t, env.Sequence, parsed.Sequence)
+		assert.JSONEq(t, `{"total":10}`, string(parsed.Payload))
+	})
+}
@@ -0,0 +1,89 @@
+// Package xuidexp assigns XUIDs to A/B experiment variants.
+//
+// Assignment uses xuid.XUID.Bucket salted with the experiment name, so it is
+// deterministic, independent between experiments, and the same in every
+// service that declares the experiment with the same variants.
+package xuidexp
+
+import (
+	"errors"
+
+	"github.com/47monad/xuid"
+)
+
+var ErrInvalidExperiment = errors.New("experiment has no variants or a non-positive weight")
+
+// Variant is an arm of an experiment. Weight is relative to the weights of
+// the other variants.
+type Variant struct {
+	Name   string
+	Weight int
+}
+
+// Exposure records that a subject was assigned a variant.
+type Exposure struct {
+	Experiment string
+	Variant    string
+	Subject    xuid.XUID
+}
+
+// Experiment assigns subjects to variants.
+type Experiment struct {
+	name     string
+	variants []Variant
+	total    int
+	onExpose func(Exposure)
+}
+
+// New returns an experiment splitting subjects between variants in
+// proportion to their weights. Changing the name, order or weights of the
+// variants reassigns subjects.
+func New(name string, variants ...Variant) (*Experiment, error) {
+	if len(variants) == 0 {
+		return nil, ErrInvalidExperiment
+	}
+	total := 0
+	for _, v := range variants {
+		if v.Weight <= 0 {
+			return nil, ErrInvalidExperiment
+		}
+		total += v.Weight
+	}
+	return &Experiment{name: name, variants: append([]Variant(nil), variants...), total: total}, nil
+}
+
+// Name returns the name of the experiment.
+func (e *Experiment) Name() string {
+	return e.name
+}
+
+// OnExposure sets the function called by Expose, typically to log exposures
+// to an analytics pipeline. It must be set before the experiment is used
+// concurrently.
+func (e *Experiment) OnExposure(f func(Exposure)) *Experiment {
+	e.onExpose = f
+	return e
+}
+
+// Assign returns the variant of subject without recording an exposure.
+func (e *Experiment) Assign(subject xuid.XUID) string {
+	b := subject.Bucket(e.total, []byte(e.name))
+	for _, v := range e.variants {
+		if b < v.Weight {
+			return v.Name
+		}
+		b -= v.Weight
+	}
+	// Unreachable: b is less than the sum of the weights.
+	return e.variants[len(e.variants)-1].Name
+}
+
+// Expose returns the variant of subject and reports the exposure to the
+// function set with OnExposure. Call it where the variant is actually shown.
+func (e *Experiment) Expose(subject xuid.XUID) string {
+	v := e.Assign(subject)
+	if e.onExpose != nil {
+		e.onExpose(Exposure{Experiment: e.name, Variant: v, Subject: subject})
+	}
+	return v
+}
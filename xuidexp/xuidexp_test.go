@@ -0,0 +1,73 @@
+package xuidexp_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidexp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("rejects experiments without variants", func(t *testing.T) {
+		_, err := xuidexp.New("checkout")
+
+		assert.ErrorIs(t, err, xuidexp.ErrInvalidExperiment)
+	})
+
+	t.Run("rejects non-positive weights", func(t *testing.T) {
+		_, err := xuidexp.New("checkout", xuidexp.Variant{Name: "control", Weight: 1}, xuidexp.Variant{Name: "new", Weight: 0})
+
+		assert.ErrorIs(t, err, xuidexp.ErrInvalidExperiment)
+	})
+}
+
+func TestAssign(t *testing.T) {
+	exp, err := xuidexp.New("checkout",
+		xuidexp.Variant{Name: "control", Weight: 3},
+		xuidexp.Variant{Name: "new", Weight: 1},
+	)
+	require.NoError(t, err)
+
+	t.Run("is deterministic", func(t *testing.T) {
+		x := xuid.MustNewRandom("user")
+
+		assert.Equal(t, exp.Assign(x), exp.Assign(x))
+	})
+
+	t.Run("follows weights", func(t *testing.T) {
+		counts := map[string]int{}
+		for i := 0; i < 4000; i++ {
+			counts[exp.Assign(xuid.MustNewRandom("user"))]++
+		}
+
+		assert.InDelta(t, 3000, counts["control"], 200)
+		assert.InDelta(t, 1000, counts["new"], 200)
+	})
+
+	t.Run("matches Bucket salted with the name", func(t *testing.T) {
+		x := xuid.MustNewRandom("user")
+		want := "control"
+		if x.Bucket(4, []byte("checkout")) >= 3 {
+			want = "new"
+		}
+
+		assert.Equal(t, want, exp.Assign(x))
+	})
+}
+
+func TestExpose(t *testing.T) {
+	var got []xuidexp.Exposure
+	exp, err := xuidexp.New("checkout", xuidexp.Variant{Name: "control", Weight: 1})
+	require.NoError(t, err)
+	exp.OnExposure(func(e xuidexp.Exposure) { got = append(got, e) })
+	x := xuid.MustNewRandom("user")
+
+	v := exp.Expose(x)
+
+	assert.Equal(t, "control", v)
+	assert.Equal(t, []xuidexp.Exposure{{Experiment: "checkout", Variant: "control", Subject: x}}, got)
+	exp.Assign(x)
+	assert.Len(t, got, 1)
+}
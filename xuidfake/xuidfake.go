@@ -0,0 +1,97 @@
+// Package xuidfake registers a gofakeit function generating XUIDs.
+//
+// Importing the package registers the "xuid" function, which fills XUID
+// struct fields from their fake tag:
+//
+//	import _ "github.com/47monad/xuid/xuidfake"
+//
+//	type User struct {
+//		ID      xuid.XUID `fake:"{xuid:user}"`
+//		Session xuid.XUID `fake:"{xuid:sess,random}"`
+//	}
+//
+//	var u User
+//	gofakeit.Struct(&u)
+//
+// The first parameter is the prefix and the optional second parameter is
+// either "sortable" (the default) or "random". Values are drawn from the
+// faker's random source, so seeded fakers produce the same XUIDs on the
+// same day.
+package xuidfake
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+)
+
+// FuncName is the name of the registered gofakeit function.
+const FuncName = "xuid"
+
+func init() {
+	gofakeit.AddFuncLookup(FuncName, gofakeit.Info{
+		Display:     "XUID",
+		Category:    "xuid",
+		Description: "Prefixed, base58 encoded UUID",
+		Example:     "user_Cg2feq9FaTyhze6o1bhbU",
+		Output:      "xuid.XUID",
+		Params: []gofakeit.Param{
+			{Field: "prefix", Display: "Prefix", Type: "string", Optional: true, Description: "Prefix of the XUID"},
+			{Field: "kind", Display: "Kind", Type: "string", Default: "sortable", Options: []string{"sortable", "random"}, Description: "Sortable (UUIDv7) or random (UUIDv4)"},
+		},
+		Generate: func(f *gofakeit.Faker, m *gofakeit.MapParams, info *gofakeit.Info) (any, error) {
+			prefix, _ := info.GetString(m, "prefix")
+			kind, err := info.GetString(m, "kind")
+			if err != nil {
+				return nil, err
+			}
+			switch kind {
+			case "sortable":
+				return Sortable(f, prefix)
+			case "random":
+				return Random(f, prefix)
+			}
+			return nil, fmt.Errorf("xuidfake: unknown kind %q", kind)
+		},
+	})
+}
+
+// Sortable returns a sortable XUID with a timestamp in the year before the
+// start of the current UTC day, so the timestamp only depends on f. It
+// fails as xuid.NewWith does for invalid prefixes.
+func Sortable(f *gofakeit.Faker, prefix string) (xuid.XUID, error) {
+	u := randomBytes(f)
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(pastMillis(f)))
+	copy(u[:6], ms[2:])
+	u[6] = (u[6] & 0x0f) | 0x70 // version 7
+	return xuid.NewWith(u, prefix)
+}
+
+// Random returns a random XUID. It fails as xuid.NewWith does for invalid
+// prefixes.
+func Random(f *gofakeit.Faker, prefix string) (xuid.XUID, error) {
+	u := randomBytes(f)
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	return xuid.NewWith(u, prefix)
+}
+
+// pastMillis returns a random Unix time in milliseconds in the year before
+// the start of the current UTC day.
+func pastMillis(f *gofakeit.Faker) int64 {
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	year := (365 * 24 * time.Hour).Milliseconds()
+	return day.UnixMilli() - 1 - int64(f.Uint64()%uint64(year))
+}
+
+func randomBytes(f *gofakeit.Faker) uuid.UUID {
+	var u uuid.UUID
+	binary.BigEndian.PutUint64(u[:8], f.Uint64())
+	binary.BigEndian.PutUint64(u[8:], f.Uint64())
+	u[8] = (u[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return u
+}
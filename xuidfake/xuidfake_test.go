@@ -0,0 +1,89 @@
+package xuidfake_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidfake"
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixture struct {
+	ID      xuid.XUID `fake:"{xuid:user}"`
+	Session xuid.XUID `fake:"{xuid:sess,random}"`
+	Plain   xuid.XUID `fake:"{xuid}"`
+	Name    string    `fake:"{firstname}"`
+}
+
+func TestStruct(t *testing.T) {
+	t.Run("fills tagged XUID fields", func(t *testing.T) {
+		var f fixture
+
+		require.NoError(t, gofakeit.Struct(&f))
+
+		assert.Equal(t, "user", f.ID.GetPrefix())
+		assert.True(t, f.ID.IsSortable())
+		assert.Equal(t, "sess", f.Session.GetPrefix())
+		assert.True(t, f.Session.IsRandom())
+		assert.Equal(t, "", f.Plain.GetPrefix())
+		assert.True(t, f.Plain.IsSortable())
+		assert.NotEmpty(t, f.Name)
+	})
+
+	t.Run("is reproducible with seeded fakers", func(t *testing.T) {
+		var f1, f2 fixture
+
+		require.NoError(t, gofakeit.New(42).Struct(&f1))
+		require.NoError(t, gofakeit.New(42).Struct(&f2))
+
+		assert.True(t, f1.ID.Equal(f2.ID))
+		assert.True(t, f1.Session.Equal(f2.Session))
+	})
+
+	t.Run("rejects unknown kinds", func(t *testing.T) {
+		var f struct {
+			ID xuid.XUID `fake:"{xuid:user,other}"`
+		}
+
+		assert.Error(t, gofakeit.Struct(&f))
+	})
+
+	t.Run("rejects invalid prefixes", func(t *testing.T) {
+		info := gofakeit.GetFuncLookup(xuidfake.FuncName)
+		params := gofakeit.NewMapParams()
+		params.Add("prefix", strings.Repeat("a", xuid.MaxPrefixLen+1))
+
+		_, err := info.Generate(gofakeit.New(1), params, info)
+
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+	})
+}
+
+func TestGenerators(t *testing.T) {
+	f := gofakeit.New(1)
+
+	t.Run("generated XUIDs parse back", func(t *testing.T) {
+		for _, gen := range []func(*gofakeit.Faker, string) (xuid.XUID, error){xuidfake.Sortable, xuidfake.Random} {
+			id, err := gen(f, "user")
+			require.NoError(t, err)
+
+			parsed, err := xuid.Parse(id.String())
+
+			require.NoError(t, err)
+			assert.True(t, id.Equal(parsed))
+		}
+	})
+
+	t.Run("rejects invalid prefixes", func(t *testing.T) {
+		long := strings.Repeat("a", xuid.MaxPrefixLen+1)
+
+		_, err1 := xuidfake.Sortable(f, long)
+		_, err2 := xuidfake.Random(f, long)
+
+		assert.ErrorIs(t, err1, xuid.ErrPrefixTooLong)
+		assert.ErrorIs(t, err2, xuid.ErrPrefixTooLong)
+	})
+}
@@ -0,0 +1,88 @@
+// Package xuidgopter provides github.com/leanovate/gopter generators for
+// XUIDs. It mirrors package xuidrapid.
+//
+// gopter drops shrinkers when mapping generated values, so failing XUIDs
+// are reported as generated. The edge prefixes and boundary timestamps are
+// drawn often enough that counterexamples are usually simple already.
+package xuidgopter
+
+import (
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidtest"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+)
+
+// Prefix generates valid prefixes.
+func Prefix() gopter.Gen {
+	prefixes := xuidtest.EdgePrefixes()
+	consts := make([]any, len(prefixes))
+	for i, p := range prefixes {
+		consts[i] = p
+	}
+	return gen.OneGenOf(
+		gen.OneConstOf(consts...),
+		gen.RegexMatch(`^[a-z][a-z0-9]{0,15}$`),
+	)
+}
+
+// Time generates timestamps representable by sortable XUIDs.
+func Time() gopter.Gen {
+	times := xuidtest.BoundaryTimes()
+	consts := make([]any, len(times))
+	for i, t := range times {
+		consts[i] = t
+	}
+	return gen.OneGenOf(
+		gen.OneConstOf(consts...),
+		gen.Int64Range(0, 1<<48-1).Map(time.UnixMilli),
+	)
+}
+
+// Sortable generates sortable XUIDs.
+func Sortable() gopter.Gen {
+	return gopter.CombineGens(
+		Prefix(),
+		Time(),
+		gen.SliceOfN(10, gen.UInt8()),
+	).Map(func(v []any) xuid.XUID {
+		var entropy [10]byte
+		copy(entropy[:], v[2].([]uint8))
+		return xuidtest.SortableFrom(v[0].(string), v[1].(time.Time), entropy)
+	})
+}
+
+// Random generates random XUIDs.
+func Random() gopter.Gen {
+	return gopter.CombineGens(
+		Prefix(),
+		gen.SliceOfN(16, gen.UInt8()),
+	).Map(func(v []any) xuid.XUID {
+		var entropy [16]byte
+		copy(entropy[:], v[1].([]uint8))
+		return xuidtest.RandomFrom(v[0].(string), entropy)
+	})
+}
+
+// XUID generates sortable and random XUIDs.
+func XUID() gopter.Gen {
+	return gen.OneGenOf(Sortable(), Random())
+}
+
+// String generates valid XUID strings.
+func String() gopter.Gen {
+	return XUID().Map(xuid.XUID.String)
+}
+
+// Adversarial generates XUID strings corrupted by xuidtest.Mutate.
+func Adversarial() gopter.Gen {
+	return gopter.CombineGens(
+		String(),
+		gen.IntRange(0, xuidtest.NumMutations-1),
+		gen.IntRange(0, xuid.MaxStringLen),
+	).Map(func(v []any) string {
+		return xuidtest.Mutate(v[0].(string), v[1].(int), v[2].(int))
+	})
+}
@@ -0,0 +1,57 @@
+package xuidgopter_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidgopter"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestGenerators(t *testing.T) {
+	properties := gopter.NewProperties(nil)
+
+	properties.Property("XUIDs round trip", prop.ForAll(
+		func(id xuid.XUID) bool {
+			parsed, err := xuid.Parse(id.String())
+			return err == nil && id.Equal(parsed)
+		},
+		xuidgopter.XUID(),
+	))
+
+	properties.Property("sortable XUIDs are sortable", prop.ForAll(
+		func(id xuid.XUID) bool {
+			return id.IsSortable()
+		},
+		xuidgopter.Sortable(),
+	))
+
+	properties.Property("random XUIDs are random", prop.ForAll(
+		func(id xuid.XUID) bool {
+			return id.IsRandom()
+		},
+		xuidgopter.Random(),
+	))
+
+	properties.Property("valid strings parse", prop.ForAll(
+		func(s string) bool {
+			return xuid.IsValid(s)
+		},
+		xuidgopter.String(),
+	))
+
+	properties.Property("accepted adversarial strings round trip", prop.ForAll(
+		func(s string) bool {
+			id, err := xuid.Parse(s)
+			if err != nil {
+				return true
+			}
+			parsed, err := xuid.Parse(id.String())
+			return err == nil && id.Equal(parsed)
+		},
+		xuidgopter.Adversarial(),
+	))
+
+	properties.TestingRun(t)
+}
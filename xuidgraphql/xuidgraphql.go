@@ -0,0 +1,64 @@
+// Package xuidgraphql helps GraphQL servers use XUIDs in global node IDs
+// and in Apollo Federation entity representations.
+package xuidgraphql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/47monad/xuid"
+)
+
+var (
+	ErrInvalidNodeID         = errors.New("invalid global node ID")
+	ErrInvalidRepresentation = errors.New("invalid entity representation")
+)
+
+// EncodeNodeID returns the global node ID of the entity of type typename
+// identified by x, as formatted by xuid.FormatGlobalID. Unlike
+// xuid.EncodeGlobalID it does not consult the prefix registry.
+func EncodeNodeID(typename string, x xuid.XUID) string {
+	return xuid.FormatGlobalID(typename, x)
+}
+
+// DecodeNodeID splits a global node ID created by EncodeNodeID into its
+// typename and XUID. Unlike xuid.DecodeGlobalID it does not consult the
+// prefix registry, which suits gateways resolving types they don't own.
+func DecodeNodeID(id string) (string, xuid.XUID, error) {
+	typename, x, err := xuid.ParseGlobalID(id)
+	if err != nil {
+		return "", xuid.XUID{}, fmt.Errorf("%w: %w", ErrInvalidNodeID, err)
+	}
+	return typename, x, nil
+}
+
+// Representation is a federated entity representation keyed by a XUID, as
+// sent by the gateway to the _entities resolver.
+type Representation struct {
+	Typename string    `json:"__typename"`
+	ID       xuid.XUID `json:"id"`
+}
+
+// NewRepresentation returns the representation of the entity of type
+// typename identified by x.
+func NewRepresentation(typename string, x xuid.XUID) map[string]any {
+	return map[string]any{"__typename": typename, "id": x.String()}
+}
+
+// ParseRepresentation reads a representation as decoded by GraphQL servers
+// for the _Any scalar. key names the field holding the XUID, usually "id".
+func ParseRepresentation(rep map[string]any, key string) (Representation, error) {
+	typename, _ := rep["__typename"].(string)
+	if typename == "" {
+		return Representation{}, fmt.Errorf("%w: missing __typename", ErrInvalidRepresentation)
+	}
+	idstr, ok := rep[key].(string)
+	if !ok {
+		return Representation{}, fmt.Errorf("%w: missing %s", ErrInvalidRepresentation, key)
+	}
+	x, err := xuid.Parse(idstr)
+	if err != nil {
+		return Representation{}, fmt.Errorf("%w: %w", ErrInvalidRepresentation, err)
+	}
+	return Representation{Typename: typename, ID: x}, nil
+}
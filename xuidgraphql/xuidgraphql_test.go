@@ -0,0 +1,100 @@
+package xuidgraphql_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidgraphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeID(t *testing.T) {
+	t.Run("encodes typename and XUID as base64", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+
+		nodeID := xuidgraphql.EncodeNodeID("User", id)
+
+		raw, err := base64.StdEncoding.DecodeString(nodeID)
+		require.NoError(t, err)
+		assert.Equal(t, "User:"+id.String(), string(raw))
+	})
+
+	t.Run("round trips", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+
+		typename, parsed, err := xuidgraphql.DecodeNodeID(xuidgraphql.EncodeNodeID("User", id))
+
+		require.NoError(t, err)
+		assert.Equal(t, "User", typename)
+		assert.True(t, id.Equal(parsed))
+	})
+
+	t.Run("rejects malformed node IDs", func(t *testing.T) {
+		inputs := []string{
+			"not base64!",
+			base64.StdEncoding.EncodeToString([]byte("no-separator")),
+			base64.StdEncoding.EncodeToString([]byte(":" + xuid.MustNewSortable("user").String())),
+			base64.StdEncoding.EncodeToString([]byte("User:invalid_id")),
+		}
+		for _, input := range inputs {
+			_, _, err := xuidgraphql.DecodeNodeID(input)
+
+			assert.ErrorIs(t, err, xuidgraphql.ErrInvalidNodeID, input)
+		}
+	})
+}
+
+func TestRepresentation(t *testing.T) {
+	t.Run("round trips through JSON", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+		data, err := json.Marshal(xuidgraphql.NewRepresentation("User", id))
+		require.NoError(t, err)
+
+		var rep map[string]any
+		require.NoError(t, json.Unmarshal(data, &rep))
+		parsed, err := xuidgraphql.ParseRepresentation(rep, "id")
+
+		require.NoError(t, err)
+		assert.Equal(t, "User", parsed.Typename)
+		assert.True(t, id.Equal(parsed.ID))
+	})
+
+	t.Run("reads custom key fields", func(t *testing.T) {
+		id := xuid.MustNewSortable("org")
+		rep := map[string]any{"__typename": "Org", "orgId": id.String()}
+
+		parsed, err := xuidgraphql.ParseRepresentation(rep, "orgId")
+
+		require.NoError(t, err)
+		assert.True(t, id.Equal(parsed.ID))
+	})
+
+	t.Run("rejects incomplete representations", func(t *testing.T) {
+		id := xuid.MustNewSortable("user").String()
+		reps := []map[string]any{
+			{"id": id},
+			{"__typename": "User"},
+			{"__typename": "User", "id": 42},
+			{"__typename": "User", "id": "invalid_id"},
+		}
+		for _, rep := range reps {
+			_, err := xuidgraphql.ParseRepresentation(rep, "id")
+
+			assert.ErrorIs(t, err, xuidgraphql.ErrInvalidRepresentation)
+		}
+	})
+
+	t.Run("decodes into struct", func(t *testing.T) {
+		id := xuid.MustNewSortable("user")
+		data := []byte(`{"__typename":"User","id":"` + id.String() + `"}`)
+
+		var rep xuidgraphql.Representation
+		require.NoError(t, json.Unmarshal(data, &rep))
+
+		assert.Equal(t, "User", rep.Typename)
+		assert.True(t, id.Equal(rep.ID))
+	})
+}
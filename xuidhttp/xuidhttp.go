@@ -0,0 +1,103 @@
+// Package xuidhttp translates the errors of package xuid into HTTP
+// responses, so every service answers bad IDs with the same status codes
+// and RFC 9457 problem details.
+package xuidhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/47monad/xuid"
+)
+
+// ContentType is the media type of problem details.
+const ContentType = "application/problem+json"
+
+// badRequest lists the errors of malformed IDs.
+var badRequest = []error{
+	xuid.ErrParse,
+	xuid.ErrInvalidUUIDString,
+	xuid.ErrPrefixTooLong,
+	xuid.ErrPrefixSeparator,
+	xuid.ErrInvalidGlobalID,
+	xuid.ErrInvalidSerial,
+	xuid.ErrInvalidGUIDBytes,
+	xuid.ErrInvalidJSONArray,
+	xuid.ErrInvalidCompactToken,
+	xuid.ErrInvalidIdempotencyKey,
+}
+
+// unprocessable lists the errors of well-formed IDs that cannot be used.
+var unprocessable = []error{
+	xuid.ErrEntityMismatch,
+	xuid.ErrPrefixMismatch,
+	xuid.ErrUnknownEntity,
+	xuid.ErrMissingXUID,
+	xuid.ErrNotSortable,
+}
+
+// StatusFor returns the HTTP status code of err: 400 Bad Request for
+// malformed IDs, such as parse errors, 422 Unprocessable Entity for valid
+// IDs of the wrong kind, such as prefix mismatches, 200 OK for nil and 500
+// Internal Server Error for other errors.
+func StatusFor(err error) int {
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case isAny(err, badRequest):
+		return http.StatusBadRequest
+	case isAny(err, unprocessable):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
+
+func isAny(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Problem is an RFC 9457 problem details object.
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Part and Offset locate parse failures, as in xuid.ParseError.
+	Part   string `json:"part,omitempty"`
+	Offset *int   `json:"offset,omitempty"`
+}
+
+// NewProblem returns the problem details of err, with the status of
+// StatusFor. The detail is the error message, except for 500 responses,
+// which could leak internals.
+func NewProblem(err error) Problem {
+	status := StatusFor(err)
+	p := Problem{Type: "about:blank", Title: http.StatusText(status), Status: status}
+	if status == http.StatusInternalServerError {
+		return p
+	}
+	p.Detail = err.Error()
+	var perr *xuid.ParseError
+	if errors.As(err, &perr) {
+		p.Part = perr.Part.String()
+		if perr.Offset >= 0 {
+			p.Offset = &perr.Offset
+		}
+	}
+	return p
+}
+
+// WriteProblem writes the problem details of err to w.
+func WriteProblem(w http.ResponseWriter, err error) {
+	p := NewProblem(err)
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
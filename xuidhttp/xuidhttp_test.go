@@ -0,0 +1,73 @@
+package xuidhttp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusFor(t *testing.T) {
+	_, parseErr := xuid.Parse("user_0OIl")
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, http.StatusOK},
+		{parseErr, http.StatusBadRequest},
+		{fmt.Errorf("path segment 1: %w", parseErr), http.StatusBadRequest},
+		{xuid.ErrPrefixTooLong, http.StatusBadRequest},
+		{fmt.Errorf("field UserID: %w", xuid.ErrPrefixMismatch), http.StatusUnprocessableEntity},
+		{xuid.ErrMissingXUID, http.StatusUnprocessableEntity},
+		{errors.New("database is down"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, xuidhttp.StatusFor(tt.err), tt.err)
+	}
+}
+
+func TestNewProblem(t *testing.T) {
+	t.Run("locates parse errors", func(t *testing.T) {
+		_, err := xuid.Parse("user_abcO123")
+
+		p := xuidhttp.NewProblem(err)
+
+		assert.Equal(t, http.StatusBadRequest, p.Status)
+		assert.Equal(t, "Bad Request", p.Title)
+		assert.Equal(t, err.Error(), p.Detail)
+		assert.Equal(t, "payload", p.Part)
+		require.NotNil(t, p.Offset)
+		assert.Equal(t, 8, *p.Offset)
+	})
+
+	t.Run("hides internal errors", func(t *testing.T) {
+		p := xuidhttp.NewProblem(errors.New("database is down"))
+
+		assert.Equal(t, http.StatusInternalServerError, p.Status)
+		assert.Empty(t, p.Detail)
+	})
+}
+
+func TestWriteProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	xuidhttp.WriteProblem(rec, xuid.ErrEntityMismatch)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, xuidhttp.ContentType, rec.Header().Get("Content-Type"))
+	var p map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	assert.Equal(t, map[string]any{
+		"type":   "about:blank",
+		"title":  "Unprocessable Entity",
+		"status": float64(422),
+		"detail": xuid.ErrEntityMismatch.Error(),
+	}, p)
+}
@@ -0,0 +1,164 @@
+// Package xuidjournal persists XUIDs in an append-only journal, such as the
+// IDs of events processed by an at-least-once consumer, so they can be
+// reloaded cheaply after a restart.
+//
+// A journal is a sequence of blocks, one per call to Writer.Write. A block
+// is the big-endian uint32 length of its payload, the big-endian CRC-32C
+// of the payload, and the payload: the XUIDs in the format of
+// xuid.XUID.CanonicalBytes, one after another.
+package xuidjournal
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/47monad/xuid"
+)
+
+// MaxBlockLen is the largest payload accepted by Reader, which protects
+// readers from allocating huge buffers for corrupt lengths.
+const MaxBlockLen = 64 << 20
+
+const headerLen = 8
+
+var (
+	ErrCorrupt   = errors.New("journal block is corrupt")
+	ErrTruncated = errors.New("journal ends with a truncated block")
+	ErrTooLarge  = errors.New("journal block is larger than MaxBlockLen")
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Writer appends blocks of XUIDs to a journal.
+type Writer struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewWriter returns a Writer appending to w, typically a file opened with
+// os.O_APPEND. Truncate the file to Reader.Offset first if reading it
+// ended with ErrTruncated, so the new blocks follow the last valid one.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write appends a block holding ids with a single call to the underlying
+// writer. A crash during the call leaves at most a truncated block, which
+// readers detect. Call Sync on files to make the block durable. Nothing is
+// written if a XUID has a prefix longer than xuid.MaxPrefixLen.
+func (w *Writer) Write(ids ...xuid.XUID) error {
+	b := append(w.buf[:0], make([]byte, headerLen)...)
+	for _, x := range ids {
+		var err error
+		if b, err = x.AppendCanonicalBytes(b); err != nil {
+			return err
+		}
+	}
+	payload := b[headerLen:]
+	if len(payload) > MaxBlockLen {
+		return ErrTooLarge
+	}
+	binary.BigEndian.PutUint32(b, uint32(len(payload)))
+	binary.BigEndian.PutUint32(b[4:], crc32.Checksum(payload, castagnoli))
+	w.buf = b
+	_, err := w.w.Write(b)
+	return err
+}
+
+// Reader reads the XUIDs of a journal.
+type Reader struct {
+	r      io.Reader
+	buf    []byte
+	block  []xuid.XUID
+	offset int64
+}
+
+// NewReader returns a Reader reading the journal from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// Read returns the next XUID of the journal. At the end of the journal it
+// returns io.EOF, or ErrTruncated if the last block was only partially
+// written, which happens when the writer crashed: the XUIDs of that block
+// were never durable. Blocks whose checksum or content is invalid fail
+// with ErrCorrupt.
+func (r *Reader) Read() (xuid.XUID, error) {
+	for len(r.block) == 0 {
+		if err := r.readBlock(); err != nil {
+			return xuid.XUID{}, err
+		}
+	}
+	x := r.block[0]
+	r.block = r.block[1:]
+	return x, nil
+}
+
+// Offset returns the length in bytes of the complete blocks read so far.
+func (r *Reader) Offset() int64 {
+	return r.offset
+}
+
+// ReadAll reads every XUID of the journal from r. A truncated last block
+// is ignored and reported by the returned offset, the length of the valid
+// part of the journal, being shorter than the journal.
+func ReadAll(r io.Reader) ([]xuid.XUID, int64, error) {
+	jr := NewReader(r)
+	var res []xuid.XUID
+	for {
+		x, err := jr.Read()
+		if errors.Is(err, io.EOF) || errors.Is(err, ErrTruncated) {
+			return res, jr.Offset(), nil
+		}
+		if err != nil {
+			return res, jr.Offset(), err
+		}
+		res = append(res, x)
+	}
+}
+
+func (r *Reader) readBlock() error {
+	var header [headerLen]byte
+	if _, err := io.ReadFull(r.r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return ErrTruncated
+		}
+		return err
+	}
+	n := binary.BigEndian.Uint32(header[:])
+	if n > MaxBlockLen {
+		return fmt.Errorf("%w: length %d at offset %d", ErrCorrupt, n, r.offset)
+	}
+	if cap(r.buf) < int(n) {
+		r.buf = make([]byte, n)
+	}
+	payload := r.buf[:n]
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return ErrTruncated
+		}
+		return err
+	}
+	if crc32.Checksum(payload, castagnoli) != binary.BigEndian.Uint32(header[4:]) {
+		return fmt.Errorf("%w: checksum mismatch at offset %d", ErrCorrupt, r.offset)
+	}
+	var block []xuid.XUID
+	for len(payload) > 0 {
+		end := 1 + int(payload[0]) + 16
+		if end > len(payload) {
+			return fmt.Errorf("%w: invalid XUID at offset %d", ErrCorrupt, r.offset)
+		}
+		x, err := xuid.ParseCanonicalBytes(payload[:end])
+		if err != nil {
+			return fmt.Errorf("%w: %w at offset %d", ErrCorrupt, err, r.offset)
+		}
+		block = append(block, x)
+		payload = payload[end:]
+	}
+	r.block = block
+	r.offset += headerLen + int64(n)
+	return nil
+}
@@ -0,0 +1,104 @@
+package xuidjournal_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidjournal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal(t *testing.T) {
+	ids := []xuid.XUID{xuid.MustNewSortable("evt"), xuid.MustNewSortable("evt"), xuid.MustNewRandom(""), xuid.MustNewSortable("order")}
+
+	journal := func(t *testing.T) []byte {
+		var buf bytes.Buffer
+		w := xuidjournal.NewWriter(&buf)
+		require.NoError(t, w.Write(ids[:2]...))
+		require.NoError(t, w.Write())
+		require.NoError(t, w.Write(ids[2:]...))
+		return buf.Bytes()
+	}
+
+	t.Run("reads XUIDs back in order", func(t *testing.T) {
+		data := journal(t)
+		r := xuidjournal.NewReader(bytes.NewReader(data))
+
+		var got []xuid.XUID
+		for {
+			x, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			got = append(got, x)
+		}
+
+		assert.Equal(t, ids, got)
+		assert.EqualValues(t, len(data), r.Offset())
+	})
+
+	t.Run("ignores a truncated last block", func(t *testing.T) {
+		data := journal(t)
+		valid := len(data) - 8 - 1 - 16 - 1 - 5 - 16
+
+		for _, cut := range []int{1, 10, len(data) - valid - 1} {
+			got, offset, err := xuidjournal.ReadAll(bytes.NewReader(data[:len(data)-cut]))
+
+			require.NoError(t, err)
+			assert.Equal(t, ids[:2], got)
+			assert.EqualValues(t, valid, offset)
+		}
+	})
+
+	t.Run("reports truncation to Read", func(t *testing.T) {
+		data := journal(t)
+		r := xuidjournal.NewReader(bytes.NewReader(data[:len(data)-1]))
+
+		var err error
+		for err == nil {
+			_, err = r.Read()
+		}
+
+		assert.ErrorIs(t, err, xuidjournal.ErrTruncated)
+	})
+
+	t.Run("detects corruption", func(t *testing.T) {
+		data := journal(t)
+		data[len(data)-1] ^= 0xff
+
+		got, _, err := xuidjournal.ReadAll(bytes.NewReader(data))
+
+		assert.ErrorIs(t, err, xuidjournal.ErrCorrupt)
+		assert.Equal(t, ids[:2], got)
+	})
+
+	t.Run("rejects prefixes longer than MaxPrefixLen without writing", func(t *testing.T) {
+		x := xuid.MustNewRandom("")
+		x.SetPrefix(strings.Repeat("a", 300))
+		var buf bytes.Buffer
+
+		err := xuidjournal.NewWriter(&buf).Write(ids[0], x)
+
+		assert.ErrorIs(t, err, xuid.ErrPrefixTooLong)
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("appends after a truncated block is cut", func(t *testing.T) {
+		data := journal(t)
+		_, offset, err := xuidjournal.ReadAll(bytes.NewReader(data[:len(data)-3]))
+		require.NoError(t, err)
+
+		buf := bytes.NewBuffer(data[:offset:offset])
+		require.NoError(t, xuidjournal.NewWriter(buf).Write(ids[3]))
+		got, _, err := xuidjournal.ReadAll(buf)
+
+		require.NoError(t, err)
+		assert.Equal(t, []xuid.XUID{ids[0], ids[1], ids[3]}, got)
+	})
+}
@@ -0,0 +1,90 @@
+// Package xuidjwt reads and writes XUIDs in JWT claims.
+//
+// Claims are handled as map[string]any, the representation used by JWT
+// libraries for untyped claims (jwt.MapClaims in golang-jwt). XUIDs are
+// always stored in their prefixed string form.
+package xuidjwt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/47monad/xuid"
+)
+
+// Registered claim names holding identifiers.
+const (
+	ClaimID      = "jti"
+	ClaimSubject = "sub"
+)
+
+var (
+	ErrMissingClaim = errors.New("claim is missing")
+	ErrInvalidClaim = errors.New("claim does not hold a valid XUID")
+)
+
+// NewJTI returns a new random XUID for the jti claim.
+func NewJTI(prefix string) (xuid.XUID, error) {
+	return xuid.NewRandom(prefix)
+}
+
+// Set stores x in claims under name.
+func Set(claims map[string]any, name string, x xuid.XUID) {
+	claims[name] = x.String()
+}
+
+// Get reads the XUID stored in claims under name.
+func Get(claims map[string]any, name string) (xuid.XUID, error) {
+	v, ok := claims[name]
+	if !ok {
+		return xuid.XUID{}, fmt.Errorf("%w: %s", ErrMissingClaim, name)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return xuid.XUID{}, fmt.Errorf("%w: %s", ErrInvalidClaim, name)
+	}
+	x, err := xuid.Parse(s)
+	if err != nil {
+		return xuid.XUID{}, fmt.Errorf("%w: %s: %w", ErrInvalidClaim, name, err)
+	}
+	return x, nil
+}
+
+// ID reads the jti claim.
+func ID(claims map[string]any) (xuid.XUID, error) {
+	return Get(claims, ClaimID)
+}
+
+// Subject reads the sub claim.
+func Subject(claims map[string]any) (xuid.XUID, error) {
+	return Get(claims, ClaimSubject)
+}
+
+// Validator checks the XUID claims of a token. Run validators after the
+// token signature has been verified.
+type Validator func(claims map[string]any) error
+
+// RequirePrefix returns a Validator checking that claim name holds a XUID
+// with the given prefix.
+func RequirePrefix(name, prefix string) Validator {
+	return func(claims map[string]any) error {
+		x, err := Get(claims, name)
+		if err != nil {
+			return err
+		}
+		if x.GetPrefix() != prefix {
+			return fmt.Errorf("%w: %s must have prefix %q", ErrInvalidClaim, name, prefix)
+		}
+		return nil
+	}
+}
+
+// Validate runs validators in order and returns the first error.
+func Validate(claims map[string]any, validators ...Validator) error {
+	for _, v := range validators {
+		if err := v(claims); err != nil {
+			return err
+		}
+	}
+	return nil
+}
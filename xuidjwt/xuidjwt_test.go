@@ -0,0 +1,80 @@
+package xuidjwt_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidjwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewJTI(t *testing.T) {
+	t.Run("creates random XUID with prefix", func(t *testing.T) {
+		jti, err := xuidjwt.NewJTI("tok")
+
+		require.NoError(t, err)
+		assert.True(t, jti.IsRandom())
+		assert.Equal(t, "tok", jti.GetPrefix())
+	})
+}
+
+func TestClaims(t *testing.T) {
+	t.Run("round trips jti and sub", func(t *testing.T) {
+		jti, _ := xuidjwt.NewJTI("tok")
+		sub := xuid.MustNewSortable("user")
+		claims := map[string]any{}
+
+		xuidjwt.Set(claims, xuidjwt.ClaimID, jti)
+		xuidjwt.Set(claims, xuidjwt.ClaimSubject, sub)
+
+		assert.Equal(t, sub.String(), claims["sub"])
+		parsedJTI, err := xuidjwt.ID(claims)
+		require.NoError(t, err)
+		assert.True(t, jti.Equal(parsedJTI))
+		parsedSub, err := xuidjwt.Subject(claims)
+		require.NoError(t, err)
+		assert.True(t, sub.Equal(parsedSub))
+	})
+
+	t.Run("reports missing claims", func(t *testing.T) {
+		_, err := xuidjwt.Subject(map[string]any{})
+
+		assert.ErrorIs(t, err, xuidjwt.ErrMissingClaim)
+	})
+
+	t.Run("reports invalid claims", func(t *testing.T) {
+		for _, v := range []any{42, "invalid_id"} {
+			_, err := xuidjwt.Subject(map[string]any{"sub": v})
+
+			assert.ErrorIs(t, err, xuidjwt.ErrInvalidClaim)
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	claims := map[string]any{}
+	xuidjwt.Set(claims, xuidjwt.ClaimSubject, xuid.MustNewSortable("user"))
+	xuidjwt.Set(claims, xuidjwt.ClaimID, xuid.MustNewRandom("tok"))
+
+	t.Run("accepts matching prefixes", func(t *testing.T) {
+		err := xuidjwt.Validate(claims,
+			xuidjwt.RequirePrefix(xuidjwt.ClaimSubject, "user"),
+			xuidjwt.RequirePrefix(xuidjwt.ClaimID, "tok"),
+		)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects other prefixes", func(t *testing.T) {
+		err := xuidjwt.Validate(claims, xuidjwt.RequirePrefix(xuidjwt.ClaimSubject, "service"))
+
+		assert.ErrorIs(t, err, xuidjwt.ErrInvalidClaim)
+	})
+
+	t.Run("rejects missing claims", func(t *testing.T) {
+		err := xuidjwt.Validate(claims, xuidjwt.RequirePrefix("act", "user"))
+
+		assert.ErrorIs(t, err, xuidjwt.ErrMissingClaim)
+	})
+}
@@ -0,0 +1,59 @@
+// Package xuidmetrics turns XUIDs into metric label and exemplar values
+// without leaking unbounded cardinality into time series.
+//
+// Label maps are handled as map[string]string, the underlying type of
+// prometheus.Labels.
+package xuidmetrics
+
+import (
+	"strconv"
+
+	"github.com/47monad/xuid"
+)
+
+// ExemplarLabel is the exemplar label name used by Exemplar.
+const ExemplarLabel = "xuid"
+
+// Labeler converts XUIDs to label values. Raw XUIDs are only used for
+// prefixes that were explicitly allowed, such as tenants, whose number is
+// known to be small.
+type Labeler struct {
+	allowed map[string]bool
+	buckets uint64
+}
+
+// NewLabeler returns a Labeler passing XUIDs with the allowed prefixes
+// through unchanged. Other XUIDs are mapped to one of buckets hash buckets
+// per prefix, so each prefix contributes at most buckets label values.
+// buckets values below 1 are treated as 1.
+func NewLabeler(buckets int, allowedPrefixes ...string) *Labeler {
+	l := &Labeler{allowed: make(map[string]bool, len(allowedPrefixes)), buckets: uint64(max(buckets, 1))}
+	for _, p := range allowedPrefixes {
+		l.allowed[p] = true
+	}
+	return l
+}
+
+// Allowed reports whether XUIDs with prefix are used as labels unchanged.
+func (l *Labeler) Allowed(prefix string) bool {
+	return l.allowed[prefix]
+}
+
+// Label returns the label value of x: its string form if its prefix is
+// allowed, or its prefix and hash bucket, such as "user:1f", otherwise.
+func (l *Labeler) Label(x xuid.XUID) string {
+	if l.Allowed(x.GetPrefix()) {
+		return x.String()
+	}
+	return x.GetPrefix() + ":" + strconv.FormatUint(x.Hash64()%l.buckets, 16)
+}
+
+// Exemplar returns exemplar labels identifying x: its string form if its
+// prefix is allowed, or the hex Hash64 of its UUID otherwise. Exemplars do
+// not create series, so the full hash is kept for correlation.
+func (l *Labeler) Exemplar(x xuid.XUID) map[string]string {
+	if l.Allowed(x.GetPrefix()) {
+		return map[string]string{ExemplarLabel: x.String()}
+	}
+	return map[string]string{ExemplarLabel: strconv.FormatUint(x.Hash64(), 16)}
+}
@@ -0,0 +1,57 @@
+package xuidmetrics_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidmetrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabel(t *testing.T) {
+	t.Run("passes allowed prefixes through", func(t *testing.T) {
+		l := xuidmetrics.NewLabeler(16, "tenant")
+		x := xuid.MustNewSortable("tenant")
+
+		assert.True(t, l.Allowed("tenant"))
+		assert.Equal(t, x.String(), l.Label(x))
+	})
+
+	t.Run("buckets other prefixes", func(t *testing.T) {
+		l := xuidmetrics.NewLabeler(16, "tenant")
+		values := make(map[string]bool)
+		for i := 0; i < 1000; i++ {
+			values[l.Label(xuid.MustNewSortable("user"))] = true
+		}
+
+		assert.LessOrEqual(t, len(values), 16)
+		assert.Greater(t, len(values), 1)
+		for v := range values {
+			assert.Regexp(t, `^user:[0-9a-f]$`, v)
+		}
+	})
+
+	t.Run("is deterministic", func(t *testing.T) {
+		l := xuidmetrics.NewLabeler(16)
+		x := xuid.MustNewSortable("user")
+
+		assert.Equal(t, l.Label(x), l.Label(x))
+	})
+
+	t.Run("treats non-positive bucket counts as one", func(t *testing.T) {
+		l := xuidmetrics.NewLabeler(0)
+
+		assert.Equal(t, "user:0", l.Label(xuid.MustNewSortable("user")))
+	})
+}
+
+func TestExemplar(t *testing.T) {
+	l := xuidmetrics.NewLabeler(16, "trace")
+
+	allowed := xuid.MustNewSortable("trace")
+	assert.Equal(t, map[string]string{xuidmetrics.ExemplarLabel: allowed.String()}, l.Exemplar(allowed))
+
+	other := xuid.MustNewSortable("user")
+	assert.Equal(t, map[string]string{xuidmetrics.ExemplarLabel: strconv.FormatUint(other.Hash64(), 16)}, l.Exemplar(other))
+}
@@ -0,0 +1,68 @@
+package xuidmigrate
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+)
+
+// Rename describes the renaming of a XUID prefix stored in a column, such as
+// "proj" becoming "workspace". UUIDs are left unchanged, so UUID columns and
+// the foreign keys referencing them need no update.
+type Rename struct {
+	Dialect xuid.Dialect
+	Table   string
+	Column  string
+	// PrefixColumn makes Column hold only prefixes, as written by
+	// xuidsql.SplitArgs, which is supported in every dialect. Otherwise
+	// Column must hold XUID strings, which requires a text dialect.
+	PrefixColumn bool
+	// Old and New are the prefixes before and after the rename.
+	Old string
+	New string
+}
+
+// Up returns the statement renaming the prefix. Strings in Column are
+// expected in the default encoding: those in EncodingLengthPrefixed are not
+// matched.
+func (r Rename) Up() ([]string, error) {
+	if _, err := xuid.NewWith(uuid.Nil, r.New); err != nil {
+		return nil, err
+	}
+	if r.PrefixColumn {
+		return []string{fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s",
+			r.Table, r.Column, r.quote(r.New), r.Column, r.quote(r.Old))}, nil
+	}
+	if r.Dialect != xuid.DialectPostgresText && r.Dialect != xuid.DialectMySQLText {
+		return nil, fmt.Errorf("%w: %q does not store prefixes", ErrUnsupportedDialect, r.Dialect)
+	}
+
+	// The prefix ends at the last separator, so the old prefix matches when
+	// the rest of the string holds no separator.
+	oldPrefix, newPrefix := withSeparator(r.Old), withSeparator(r.New)
+	oldLen := utf8.RuneCountInString(oldPrefix)
+	rest := fmt.Sprintf("SUBSTRING(%s, %d)", r.Column, oldLen+1)
+	return []string{fmt.Sprintf("UPDATE %s SET %s = CONCAT(%s, %s) WHERE LEFT(%s, %d) = %s AND POSITION(%s IN %s) = 0",
+		r.Table, r.Column, r.quote(newPrefix), rest,
+		r.Column, oldLen, r.quote(oldPrefix),
+		r.quote(xuid.Separator), rest)}, nil
+}
+
+// quote returns s as a string literal of the dialect.
+func (r Rename) quote(s string) string {
+	if r.Dialect == xuid.DialectMySQL || r.Dialect == xuid.DialectMySQLText {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// withSeparator returns the string form of prefix before the payload.
+func withSeparator(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+	return prefix + xuid.Separator
+}
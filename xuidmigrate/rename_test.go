@@ -0,0 +1,43 @@
+package xuidmigrate_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidmigrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRename(t *testing.T) {
+	t.Run("rewrites XUID strings in text dialects", func(t *testing.T) {
+		stmts, err := xuidmigrate.Rename{Dialect: xuid.DialectPostgresText, Table: "projects", Column: "id", Old: "proj", New: "workspace"}.Up()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"UPDATE projects SET id = CONCAT('workspace_', SUBSTRING(id, 6)) WHERE LEFT(id, 5) = 'proj_' AND POSITION('_' IN SUBSTRING(id, 6)) = 0",
+		}, stmts)
+	})
+
+	t.Run("adds a prefix to bare XUIDs", func(t *testing.T) {
+		stmts, err := xuidmigrate.Rename{Dialect: xuid.DialectMySQLText, Table: "projects", Column: "id", New: "workspace"}.Up()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"UPDATE projects SET id = CONCAT('workspace_', SUBSTRING(id, 1)) WHERE LEFT(id, 0) = '' AND POSITION('_' IN SUBSTRING(id, 1)) = 0",
+		}, stmts)
+	})
+
+	t.Run("updates prefix columns in any dialect", func(t *testing.T) {
+		stmts, err := xuidmigrate.Rename{Dialect: xuid.DialectMySQL, Table: "projects", Column: "id_prefix", PrefixColumn: true, Old: `o'\`, New: "workspace"}.Up()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{`UPDATE projects SET id_prefix = 'workspace' WHERE id_prefix = 'o''\\'`}, stmts)
+	})
+
+	t.Run("rejects UUID columns", func(t *testing.T) {
+		_, err := xuidmigrate.Rename{Dialect: xuid.DialectPostgres, Table: "projects", Column: "id", Old: "proj", New: "workspace"}.Up()
+
+		assert.ErrorIs(t, err, xuidmigrate.ErrUnsupportedDialect)
+	})
+}
@@ -0,0 +1,171 @@
+// Package xuidmigrate generates the SQL converting existing UUID primary
+// keys, and the foreign keys referencing them, to the storage used by XUIDs
+// in a given dialect.
+//
+// The statements are plain SQL, so they can be pasted into goose or atlas
+// migration files or executed from a Go migration:
+//
+//	stmts, err := xuidmigrate.Plan{
+//		Dialect: xuid.DialectPostgres,
+//		Table:   "users",
+//		Column:  "id",
+//		Source:  xuidmigrate.SourceText,
+//		ForeignKeys: []xuidmigrate.ForeignKey{
+//			{Name: "orders_user_id_fkey", Table: "orders", Column: "user_id", OnDelete: "CASCADE"},
+//		},
+//	}.Up()
+//
+// Conversions only change the column type: prefixes are not stored, as
+// with Value. Text dialects, which store prefixes, are not supported.
+package xuidmigrate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/47monad/xuid"
+)
+
+var (
+	ErrUnsupportedDialect = errors.New("dialect is not supported by migrations")
+	ErrMissingConstraint  = errors.New("constraint name is required by the dialect")
+)
+
+// Source describes the current type of the columns to convert.
+type Source int
+
+const (
+	// SourceText columns hold hyphenated UUID strings, such as CHAR(36),
+	// VARCHAR2(36) or text.
+	SourceText Source = iota
+	// SourceNative columns already use the UUID type of the dialect, such
+	// as uuid or UNIQUEIDENTIFIER, and are left unchanged.
+	SourceNative
+)
+
+// ForeignKey is a constraint referencing the converted primary key. It is
+// dropped before the conversion and added back afterwards.
+type ForeignKey struct {
+	// Name is the name of the constraint.
+	Name string
+	// Table and Column identify the referencing column.
+	Table  string
+	Column string
+	// Nullable keeps the referencing column nullable in dialects where the
+	// column is recreated.
+	Nullable bool
+	// OnDelete and OnUpdate are the referential actions, such as
+	// "CASCADE", or empty for the default.
+	OnDelete string
+	OnUpdate string
+}
+
+// Plan describes the conversion of a primary key column.
+type Plan struct {
+	Dialect xuid.Dialect
+	Table   string
+	Column  string
+	// PrimaryKey is the name of the primary key constraint, required by
+	// DialectMSSQL to recreate it.
+	PrimaryKey  string
+	Source      Source
+	ForeignKeys []ForeignKey
+}
+
+// Up returns the statements converting the primary key and the columns
+// referencing it, in execution order. Run them in a single transaction
+// where the dialect supports transactional DDL.
+func (p Plan) Up() ([]string, error) {
+	switch p.Dialect {
+	case xuid.DialectPostgres, xuid.DialectMySQL, xuid.DialectOracle:
+	case xuid.DialectMSSQL:
+		if p.PrimaryKey == "" && p.Source == SourceText {
+			return nil, fmt.Errorf("%w: primary key of %s", ErrMissingConstraint, p.Table)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedDialect, p.Dialect)
+	}
+	for _, fk := range p.ForeignKeys {
+		if fk.Name == "" {
+			return nil, fmt.Errorf("%w: foreign key %s.%s", ErrMissingConstraint, fk.Table, fk.Column)
+		}
+	}
+	if p.Source == SourceNative {
+		return nil, nil
+	}
+
+	var stmts []string
+	for _, fk := range p.ForeignKeys {
+		if p.Dialect == xuid.DialectMySQL {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP FOREIGN KEY %s", fk.Table, fk.Name))
+		} else {
+			stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", fk.Table, fk.Name))
+		}
+	}
+	stmts = append(stmts, p.convert(p.Table, p.Column, false, true)...)
+	for _, fk := range p.ForeignKeys {
+		stmts = append(stmts, p.convert(fk.Table, fk.Column, fk.Nullable, false)...)
+	}
+	for _, fk := range p.ForeignKeys {
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)", fk.Table, fk.Name, fk.Column, p.Table, p.Column)
+		if fk.OnDelete != "" {
+			stmt += " ON DELETE " + fk.OnDelete
+		}
+		if fk.OnUpdate != "" {
+			stmt += " ON UPDATE " + fk.OnUpdate
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts, nil
+}
+
+// convert returns the statements converting a text column to the UUID type
+// of the dialect.
+func (p Plan) convert(table, column string, nullable, pk bool) []string {
+	switch p.Dialect {
+	case xuid.DialectPostgres:
+		return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE uuid USING %s::uuid", table, column, column)}
+	case xuid.DialectMSSQL:
+		null := " NOT NULL"
+		if nullable {
+			null = " NULL"
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s UNIQUEIDENTIFIER%s", table, column, null)
+		if !pk {
+			return []string{stmt}
+		}
+		return []string{
+			fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", table, p.PrimaryKey),
+			stmt,
+			fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s PRIMARY KEY (%s)", table, p.PrimaryKey, column),
+		}
+	}
+
+	// MySQL and Oracle cannot convert the type in place: the values are
+	// copied to a new column, which then replaces the old one.
+	tmp := column + "__xuid"
+	colType, conv := "BINARY(16)", "UUID_TO_BIN(%s)"
+	modify := "ALTER TABLE %s MODIFY %s BINARY(16) NOT NULL"
+	if p.Dialect == xuid.DialectOracle {
+		colType, conv = "RAW(16)", "HEXTORAW(REPLACE(%s, '-', ''))"
+		modify = "ALTER TABLE %s MODIFY (%s NOT NULL)"
+	}
+	stmts := []string{
+		fmt.Sprintf("ALTER TABLE %s ADD %s %s", table, tmp, colType),
+		fmt.Sprintf("UPDATE %s SET %s = "+conv, table, tmp, column),
+	}
+	if pk {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY", table))
+	}
+	stmts = append(stmts,
+		fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", table, column),
+		fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", table, tmp, column),
+	)
+	if !nullable {
+		stmts = append(stmts, fmt.Sprintf(modify, table, column))
+	}
+	if pk {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", table, column))
+	}
+	return stmts
+}
@@ -0,0 +1,112 @@
+package xuidmigrate_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidmigrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func plan(dialect xuid.Dialect) xuidmigrate.Plan {
+	return xuidmigrate.Plan{
+		Dialect:    dialect,
+		Table:      "users",
+		Column:     "id",
+		PrimaryKey: "users_pkey",
+		ForeignKeys: []xuidmigrate.ForeignKey{
+			{Name: "orders_user_id_fkey", Table: "orders", Column: "user_id", OnDelete: "CASCADE"},
+		},
+	}
+}
+
+func TestUp(t *testing.T) {
+	t.Run("converts text columns in Postgres", func(t *testing.T) {
+		stmts, err := plan(xuid.DialectPostgres).Up()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"ALTER TABLE orders DROP CONSTRAINT orders_user_id_fkey",
+			"ALTER TABLE users ALTER COLUMN id TYPE uuid USING id::uuid",
+			"ALTER TABLE orders ALTER COLUMN user_id TYPE uuid USING user_id::uuid",
+			"ALTER TABLE orders ADD CONSTRAINT orders_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE",
+		}, stmts)
+	})
+
+	t.Run("copies values to binary columns in MySQL", func(t *testing.T) {
+		p := plan(xuid.DialectMySQL)
+		p.ForeignKeys[0].Nullable = true
+
+		stmts, err := p.Up()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"ALTER TABLE orders DROP FOREIGN KEY orders_user_id_fkey",
+			"ALTER TABLE users ADD id__xuid BINARY(16)",
+			"UPDATE users SET id__xuid = UUID_TO_BIN(id)",
+			"ALTER TABLE users DROP PRIMARY KEY",
+			"ALTER TABLE users DROP COLUMN id",
+			"ALTER TABLE users RENAME COLUMN id__xuid TO id",
+			"ALTER TABLE users MODIFY id BINARY(16) NOT NULL",
+			"ALTER TABLE users ADD PRIMARY KEY (id)",
+			"ALTER TABLE orders ADD user_id__xuid BINARY(16)",
+			"UPDATE orders SET user_id__xuid = UUID_TO_BIN(user_id)",
+			"ALTER TABLE orders DROP COLUMN user_id",
+			"ALTER TABLE orders RENAME COLUMN user_id__xuid TO user_id",
+			"ALTER TABLE orders ADD CONSTRAINT orders_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE",
+		}, stmts)
+	})
+
+	t.Run("converts to RAW(16) in Oracle", func(t *testing.T) {
+		stmts, err := plan(xuid.DialectOracle).Up()
+
+		require.NoError(t, err)
+		assert.Contains(t, stmts, "UPDATE users SET id__xuid = HEXTORAW(REPLACE(id, '-', ''))")
+		assert.Contains(t, stmts, "ALTER TABLE orders MODIFY (user_id NOT NULL)")
+	})
+
+	t.Run("recreates the primary key in SQL Server", func(t *testing.T) {
+		stmts, err := plan(xuid.DialectMSSQL).Up()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"ALTER TABLE orders DROP CONSTRAINT orders_user_id_fkey",
+			"ALTER TABLE users DROP CONSTRAINT users_pkey",
+			"ALTER TABLE users ALTER COLUMN id UNIQUEIDENTIFIER NOT NULL",
+			"ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (id)",
+			"ALTER TABLE orders ALTER COLUMN user_id UNIQUEIDENTIFIER NOT NULL",
+			"ALTER TABLE orders ADD CONSTRAINT orders_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE",
+		}, stmts)
+	})
+
+	t.Run("leaves native columns unchanged", func(t *testing.T) {
+		p := plan(xuid.DialectPostgres)
+		p.Source = xuidmigrate.SourceNative
+
+		stmts, err := p.Up()
+
+		require.NoError(t, err)
+		assert.Empty(t, stmts)
+	})
+
+	t.Run("requires constraint names", func(t *testing.T) {
+		p := plan(xuid.DialectMSSQL)
+		p.PrimaryKey = ""
+		_, err := p.Up()
+		assert.ErrorIs(t, err, xuidmigrate.ErrMissingConstraint)
+
+		p = plan(xuid.DialectPostgres)
+		p.ForeignKeys[0].Name = ""
+		_, err = p.Up()
+		assert.ErrorIs(t, err, xuidmigrate.ErrMissingConstraint)
+	})
+
+	t.Run("rejects unsupported dialects", func(t *testing.T) {
+		for _, d := range []xuid.Dialect{xuid.DialectSQLite, xuid.DialectPostgresText, "unknown"} {
+			_, err := plan(d).Up()
+
+			assert.ErrorIs(t, err, xuidmigrate.ErrUnsupportedDialect)
+		}
+	})
+}
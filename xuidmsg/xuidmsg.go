@@ -0,0 +1,89 @@
+// Package xuidmsg carries XUID correlation IDs through message brokers.
+//
+// Headers are handled as map[string][]string, the underlying type of
+// nats.Header, and tables as map[string]any, the underlying type of
+// amqp091.Table, so values of those types can be passed directly:
+//
+//	msg := nats.NewMsg("orders.created")
+//	xuidmsg.SetHeader(msg.Header, requestID)
+//
+//	pub := amqp.Publishing{CorrelationId: requestID.String(), Headers: amqp.Table{}}
+//	xuidmsg.SetTable(pub.Headers, requestID)
+//
+// Correlation IDs are always stored in their prefixed string form.
+package xuidmsg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/47monad/xuid"
+)
+
+// CorrelationHeader is the header and table key holding the correlation ID.
+const CorrelationHeader = "Correlation-Id"
+
+var (
+	ErrMissingCorrelation = errors.New("correlation ID is missing")
+	ErrInvalidCorrelation = errors.New("correlation ID is not a valid XUID")
+)
+
+// SetHeader stores x in h under CorrelationHeader, replacing any value.
+func SetHeader(h map[string][]string, x xuid.XUID) {
+	h[CorrelationHeader] = []string{x.String()}
+}
+
+// FromHeader reads the correlation ID stored in h by SetHeader.
+func FromHeader(h map[string][]string) (xuid.XUID, error) {
+	values := h[CorrelationHeader]
+	if len(values) == 0 {
+		return xuid.XUID{}, ErrMissingCorrelation
+	}
+	return Parse(values[0])
+}
+
+// SetTable stores x in t under CorrelationHeader, replacing any value.
+func SetTable(t map[string]any, x xuid.XUID) {
+	t[CorrelationHeader] = x.String()
+}
+
+// FromTable reads the correlation ID stored in t by SetTable.
+func FromTable(t map[string]any) (xuid.XUID, error) {
+	v, ok := t[CorrelationHeader]
+	if !ok {
+		return xuid.XUID{}, ErrMissingCorrelation
+	}
+	s, ok := v.(string)
+	if !ok {
+		return xuid.XUID{}, fmt.Errorf("%w: unsupported type %T", ErrInvalidCorrelation, v)
+	}
+	return Parse(s)
+}
+
+// Parse parses a correlation ID read from a dedicated message property,
+// such as the AMQP correlation-id.
+func Parse(s string) (xuid.XUID, error) {
+	if s == "" {
+		return xuid.XUID{}, ErrMissingCorrelation
+	}
+	x, err := xuid.Parse(s)
+	if err != nil {
+		return xuid.XUID{}, fmt.Errorf("%w: %w", ErrInvalidCorrelation, err)
+	}
+	return x, nil
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the correlation ID x, for
+// handlers that publish follow-up messages.
+func NewContext(ctx context.Context, x xuid.XUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, x)
+}
+
+// FromContext returns the correlation ID stored in ctx by NewContext.
+func FromContext(ctx context.Context) (xuid.XUID, bool) {
+	x, ok := ctx.Value(contextKey{}).(xuid.XUID)
+	return x, ok
+}
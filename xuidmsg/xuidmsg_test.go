@@ -0,0 +1,92 @@
+package xuidmsg_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidmsg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// header and table mirror nats.Header and amqp091.Table.
+type (
+	header map[string][]string
+	table  map[string]any
+)
+
+func TestHeader(t *testing.T) {
+	t.Run("round-trips correlation ID", func(t *testing.T) {
+		h := header{}
+		id := xuid.MustNewSortable("req")
+
+		xuidmsg.SetHeader(h, id)
+		got, err := xuidmsg.FromHeader(h)
+
+		require.NoError(t, err)
+		assert.True(t, id.Equal(got))
+		assert.Equal(t, []string{id.String()}, h[xuidmsg.CorrelationHeader])
+	})
+
+	t.Run("replaces existing value", func(t *testing.T) {
+		h := header{xuidmsg.CorrelationHeader: {"old", "values"}}
+		id := xuid.MustNewSortable("req")
+
+		xuidmsg.SetHeader(h, id)
+
+		assert.Equal(t, []string{id.String()}, h[xuidmsg.CorrelationHeader])
+	})
+
+	t.Run("reports missing and invalid IDs", func(t *testing.T) {
+		_, err := xuidmsg.FromHeader(header{})
+		assert.ErrorIs(t, err, xuidmsg.ErrMissingCorrelation)
+
+		_, err = xuidmsg.FromHeader(header{xuidmsg.CorrelationHeader: {"req_0OIl"}})
+		assert.ErrorIs(t, err, xuidmsg.ErrInvalidCorrelation)
+		assert.ErrorIs(t, err, xuid.ErrParse)
+	})
+}
+
+func TestTable(t *testing.T) {
+	t.Run("round-trips correlation ID", func(t *testing.T) {
+		tbl := table{}
+		id := xuid.MustNewSortable("req")
+
+		xuidmsg.SetTable(tbl, id)
+		got, err := xuidmsg.FromTable(tbl)
+
+		require.NoError(t, err)
+		assert.True(t, id.Equal(got))
+	})
+
+	t.Run("reports missing and invalid IDs", func(t *testing.T) {
+		_, err := xuidmsg.FromTable(table{})
+		assert.ErrorIs(t, err, xuidmsg.ErrMissingCorrelation)
+
+		_, err = xuidmsg.FromTable(table{xuidmsg.CorrelationHeader: 42})
+		assert.ErrorIs(t, err, xuidmsg.ErrInvalidCorrelation)
+	})
+}
+
+func TestParse(t *testing.T) {
+	id := xuid.MustNewSortable("req")
+
+	got, err := xuidmsg.Parse(id.String())
+	require.NoError(t, err)
+	assert.True(t, id.Equal(got))
+
+	_, err = xuidmsg.Parse("")
+	assert.ErrorIs(t, err, xuidmsg.ErrMissingCorrelation)
+}
+
+func TestContext(t *testing.T) {
+	id := xuid.MustNewSortable("req")
+
+	got, ok := xuidmsg.FromContext(xuidmsg.NewContext(context.Background(), id))
+	require.True(t, ok)
+	assert.True(t, id.Equal(got))
+
+	_, ok = xuidmsg.FromContext(context.Background())
+	assert.False(t, ok)
+}
@@ -0,0 +1,55 @@
+// Package xuidorm provides XUID column types for ORMs that need hooks beyond
+// database/sql's Scanner and Valuer interfaces.
+//
+// bun appends and scans columns through driver.Valuer and sql.Scanner, which
+// xuid.XUID already implements, so ID works as a bun column out of the box:
+//
+//	type User struct {
+//		ID   xuidorm.ID `bun:"id,pk,type:uuid"`
+//		Name string
+//	}
+//
+// sqlboiler's randomize.Struct, used by its generated tests, fills fields
+// through the Randomizer interface, which ID implements.
+package xuidorm
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+)
+
+var (
+	_ driver.Valuer = ID{}
+	_ sql.Scanner   = (*ID)(nil)
+)
+
+// ID is a XUID to be used as a model field.
+// All XUID methods, including Value and Scan, are promoted.
+type ID struct {
+	xuid.XUID
+}
+
+// Wrap returns x as an ID.
+func Wrap(x xuid.XUID) ID {
+	return ID{XUID: x}
+}
+
+// Randomize implements sqlboiler's randomize.Randomizer interface.
+// The UUID is built from nextInt so that values are unique within a test
+// run; the prefix is left untouched.
+func (id *ID) Randomize(nextInt func() int64, fieldType string, shouldBeNull bool) {
+	if shouldBeNull {
+		id.XUID, _ = xuid.NewWith(uuid.Nil, id.GetPrefix())
+		return
+	}
+	var u uuid.UUID
+	binary.BigEndian.PutUint64(u[:8], uint64(nextInt()))
+	binary.BigEndian.PutUint64(u[8:], uint64(nextInt()))
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // RFC 4122 variant
+	id.XUID, _ = xuid.NewWith(u, id.GetPrefix())
+}
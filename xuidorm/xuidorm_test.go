@@ -0,0 +1,75 @@
+package xuidorm_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidorm"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomize(t *testing.T) {
+	counter := int64(0)
+	nextInt := func() int64 {
+		counter++
+		return counter
+	}
+
+	t.Run("generates unique random UUIDs", func(t *testing.T) {
+		var id1, id2 xuidorm.ID
+
+		id1.Randomize(nextInt, "uuid", false)
+		id2.Randomize(nextInt, "uuid", false)
+
+		assert.True(t, id1.IsRandom())
+		assert.Equal(t, uuid.RFC4122, id1.GetUUID().Variant())
+		assert.False(t, id1.Equal(id2.XUID))
+	})
+
+	t.Run("keeps prefix", func(t *testing.T) {
+		id := xuidorm.Wrap(xuid.MustNewSortable("user"))
+
+		id.Randomize(nextInt, "uuid", false)
+
+		assert.Equal(t, "user", id.GetPrefix())
+	})
+
+	t.Run("generates nil UUID when field should be null", func(t *testing.T) {
+		id := xuidorm.Wrap(xuid.MustNewSortable("user"))
+
+		id.Randomize(nextInt, "uuid", true)
+
+		assert.True(t, xuid.IsEmpty(id.XUID))
+		value, err := id.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+}
+
+func TestIDPromotesCodecs(t *testing.T) {
+	t.Run("round trips through SQL value", func(t *testing.T) {
+		id := xuidorm.Wrap(xuid.MustNewSortable("user"))
+
+		value, err := id.Value()
+		require.NoError(t, err)
+
+		var loaded xuidorm.ID
+		require.NoError(t, loaded.Scan(value))
+		assert.Equal(t, id.GetUUID(), loaded.GetUUID())
+	})
+
+	t.Run("round trips through JSON", func(t *testing.T) {
+		id := xuidorm.Wrap(xuid.MustNewSortable("user"))
+
+		data, err := json.Marshal(id)
+		require.NoError(t, err)
+		assert.Equal(t, `"`+id.String()+`"`, string(data))
+
+		var parsed xuidorm.ID
+		require.NoError(t, json.Unmarshal(data, &parsed))
+		assert.True(t, id.Equal(parsed.XUID))
+	})
+}
@@ -0,0 +1,74 @@
+// Package xuidrapid provides pgregory.net/rapid generators for XUIDs.
+//
+// Generators draw prefixes from xuidtest.EdgePrefixes and timestamps from
+// xuidtest.BoundaryTimes as often as from arbitrary values, and list the
+// simplest choices first, so failing cases shrink towards short prefixes
+// and early timestamps.
+package xuidrapid
+
+import (
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidtest"
+	"pgregory.net/rapid"
+)
+
+// Prefix generates valid prefixes.
+func Prefix() *rapid.Generator[string] {
+	return rapid.OneOf(
+		rapid.SampledFrom(xuidtest.EdgePrefixes()),
+		rapid.StringMatching(`[a-z][a-z0-9]{0,15}`),
+	)
+}
+
+// Time generates timestamps representable by sortable XUIDs.
+func Time() *rapid.Generator[time.Time] {
+	return rapid.OneOf(
+		rapid.SampledFrom(xuidtest.BoundaryTimes()),
+		rapid.Map(rapid.Int64Range(0, 1<<48-1), time.UnixMilli),
+	)
+}
+
+// Sortable generates sortable XUIDs.
+func Sortable() *rapid.Generator[xuid.XUID] {
+	return rapid.Custom(func(t *rapid.T) xuid.XUID {
+		prefix := Prefix().Draw(t, "prefix")
+		at := Time().Draw(t, "time")
+		var entropy [10]byte
+		copy(entropy[:], rapid.SliceOfN(rapid.Byte(), 10, 10).Draw(t, "entropy"))
+		return xuidtest.SortableFrom(prefix, at, entropy)
+	})
+}
+
+// Random generates random XUIDs.
+func Random() *rapid.Generator[xuid.XUID] {
+	return rapid.Custom(func(t *rapid.T) xuid.XUID {
+		prefix := Prefix().Draw(t, "prefix")
+		var entropy [16]byte
+		copy(entropy[:], rapid.SliceOfN(rapid.Byte(), 16, 16).Draw(t, "entropy"))
+		return xuidtest.RandomFrom(prefix, entropy)
+	})
+}
+
+// XUID generates sortable and random XUIDs.
+func XUID() *rapid.Generator[xuid.XUID] {
+	return rapid.OneOf(Sortable(), Random())
+}
+
+// String generates valid XUID strings.
+func String() *rapid.Generator[string] {
+	return rapid.Map(XUID(), xuid.XUID.String)
+}
+
+// Adversarial generates XUID strings corrupted by xuidtest.Mutate. Most of
+// them are invalid, which makes the generator suited to testing error
+// handling around Parse.
+func Adversarial() *rapid.Generator[string] {
+	return rapid.Custom(func(t *rapid.T) string {
+		s := String().Draw(t, "xuid")
+		op := rapid.IntRange(0, xuidtest.NumMutations-1).Draw(t, "mutation")
+		pos := rapid.IntRange(0, len(s)).Draw(t, "position")
+		return xuidtest.Mutate(s, op, pos)
+	})
+}
@@ -0,0 +1,48 @@
+package xuidrapid_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidrapid"
+	"pgregory.net/rapid"
+)
+
+func TestXUID(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		id := xuidrapid.XUID().Draw(t, "id")
+
+		parsed, err := xuid.Parse(id.String())
+		if err != nil {
+			t.Fatalf("parse %q: %v", id, err)
+		}
+		if !id.Equal(parsed) {
+			t.Fatalf("round trip of %q gave %q", id, parsed)
+		}
+	})
+}
+
+func TestSortable(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		id := xuidrapid.Sortable().Draw(t, "id")
+
+		if !id.IsSortable() {
+			t.Fatalf("%q is not sortable", id)
+		}
+	})
+}
+
+func TestAdversarial(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := xuidrapid.Adversarial().Draw(t, "s")
+
+		id, err := xuid.Parse(s)
+		if err != nil {
+			return
+		}
+		parsed, err := xuid.Parse(id.String())
+		if err != nil || !id.Equal(parsed) {
+			t.Fatalf("accepted %q but cannot round trip it", s)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+// Package xuidsession issues opaque session tokens that combine a random
+// XUID with an expiry time, authenticated with HMAC-SHA256.
+//
+// A token has the form "<xuid>.<tag>", where tag is the unpadded base64url
+// encoding of the big-endian Unix expiry time followed by the HMAC of the
+// XUID string and the expiry. The XUID is readable for logging and lookups,
+// but only the holder of the key can mint or extend tokens.
+package xuidsession
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/47monad/xuid"
+)
+
+// MinKeyLen is the minimum length of HMAC keys accepted by New.
+const MinKeyLen = 32
+
+var (
+	ErrKeyTooShort      = errors.New("session key is shorter than MinKeyLen")
+	ErrMalformedToken   = errors.New("session token is malformed")
+	ErrInvalidSignature = errors.New("session token signature is invalid")
+	ErrExpired          = errors.New("session token has expired")
+)
+
+const tagLen = 8 + sha256.Size
+
+// Issuer issues and verifies session tokens.
+type Issuer struct {
+	key    []byte
+	prefix string
+}
+
+// New returns an Issuer signing tokens with key, whose session XUIDs carry
+// prefix.
+func New(key []byte, prefix string) (*Issuer, error) {
+	if len(key) < MinKeyLen {
+		return nil, ErrKeyTooShort
+	}
+	return &Issuer{key: append([]byte(nil), key...), prefix: prefix}, nil
+}
+
+// Issue creates a new session valid for ttl.
+func (i *Issuer) Issue(ttl time.Duration) (string, xuid.XUID, time.Time, error) {
+	id, err := xuid.NewRandom(i.prefix)
+	if err != nil {
+		return "", xuid.XUID{}, time.Time{}, err
+	}
+	exp := time.Now().Add(ttl).Truncate(time.Second)
+	return i.Sign(id, exp), id, exp, nil
+}
+
+// Sign returns the token of session id expiring at exp.
+// Expiry times are stored with second precision.
+func (i *Issuer) Sign(id xuid.XUID, exp time.Time) string {
+	idstr := id.String()
+	tag := make([]byte, 8, tagLen)
+	binary.BigEndian.PutUint64(tag, uint64(exp.Unix()))
+	tag = append(tag, i.mac(idstr, tag[:8])...)
+	return idstr + "." + base64.RawURLEncoding.EncodeToString(tag)
+}
+
+// Verify checks the signature and expiry of token and returns its session
+// XUID and expiry time.
+func (i *Issuer) Verify(token string) (xuid.XUID, time.Time, error) {
+	return i.VerifyAt(token, time.Now())
+}
+
+// VerifyAt is like Verify but checks expiry against now.
+func (i *Issuer) VerifyAt(token string, now time.Time) (xuid.XUID, time.Time, error) {
+	idstr, tag, err := split(token)
+	if err != nil {
+		return xuid.XUID{}, time.Time{}, err
+	}
+	if !hmac.Equal(tag[8:], i.mac(idstr, tag[:8])) {
+		return xuid.XUID{}, time.Time{}, ErrInvalidSignature
+	}
+	id, exp, err := decode(idstr, tag)
+	if err != nil {
+		return xuid.XUID{}, time.Time{}, err
+	}
+	if !now.Before(exp) {
+		return id, exp, ErrExpired
+	}
+	return id, exp, nil
+}
+
+// Parse returns the session XUID and expiry time of token without
+// verifying it. Never trust the result for authentication.
+func Parse(token string) (xuid.XUID, time.Time, error) {
+	idstr, tag, err := split(token)
+	if err != nil {
+		return xuid.XUID{}, time.Time{}, err
+	}
+	return decode(idstr, tag)
+}
+
+func (i *Issuer) mac(idstr string, exp []byte) []byte {
+	h := hmac.New(sha256.New, i.key)
+	h.Write([]byte(idstr))
+	h.Write(exp)
+	return h.Sum(nil)
+}
+
+// split splits token at its last ".", since prefixes may contain dots but
+// the base64url tag never does.
+func split(token string) (string, []byte, error) {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return "", nil, ErrMalformedToken
+	}
+	idstr, enc := token[:i], token[i+1:]
+	tag, err := base64.RawURLEncoding.DecodeString(enc)
+	if err != nil || len(tag) != tagLen {
+		return "", nil, ErrMalformedToken
+	}
+	return idstr, tag, nil
+}
+
+func decode(idstr string, tag []byte) (xuid.XUID, time.Time, error) {
+	id, err := xuid.Parse(idstr)
+	if err != nil {
+		return xuid.XUID{}, time.Time{}, ErrMalformedToken
+	}
+	return id, time.Unix(int64(binary.BigEndian.Uint64(tag[:8])), 0), nil
+}
@@ -0,0 +1,108 @@
+package xuidsession_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidsession"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = bytes.Repeat([]byte{7}, xuidsession.MinKeyLen)
+
+func TestNew(t *testing.T) {
+	t.Run("rejects short keys", func(t *testing.T) {
+		_, err := xuidsession.New([]byte("short"), "sess")
+
+		assert.ErrorIs(t, err, xuidsession.ErrKeyTooShort)
+	})
+}
+
+func TestIssueAndVerify(t *testing.T) {
+	issuer, err := xuidsession.New(testKey, "sess")
+	require.NoError(t, err)
+
+	t.Run("verifies issued tokens", func(t *testing.T) {
+		token, id, exp, err := issuer.Issue(time.Hour)
+		require.NoError(t, err)
+
+		parsedID, parsedExp, err := issuer.Verify(token)
+
+		require.NoError(t, err)
+		assert.True(t, id.IsRandom())
+		assert.Equal(t, "sess", id.GetPrefix())
+		assert.True(t, id.Equal(parsedID))
+		assert.True(t, exp.Equal(parsedExp))
+		assert.True(t, strings.HasPrefix(token, id.String()+"."))
+	})
+
+	t.Run("verifies tokens of prefixes with dots", func(t *testing.T) {
+		dotted, err := xuidsession.New(testKey, "app.sess")
+		require.NoError(t, err)
+		token, id, exp, err := dotted.Issue(time.Hour)
+		require.NoError(t, err)
+
+		parsedID, parsedExp, err := dotted.Verify(token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "app.sess", parsedID.GetPrefix())
+		assert.True(t, id.Equal(parsedID))
+		assert.True(t, exp.Equal(parsedExp))
+	})
+
+	t.Run("rejects expired tokens", func(t *testing.T) {
+		exp := time.Unix(1700000000, 0)
+		token := issuer.Sign(xuid.MustNewRandom("sess"), exp)
+
+		_, _, err := issuer.VerifyAt(token, exp.Add(-time.Second))
+		assert.NoError(t, err)
+
+		_, _, err = issuer.VerifyAt(token, exp)
+		assert.ErrorIs(t, err, xuidsession.ErrExpired)
+	})
+
+	t.Run("rejects tokens signed with another key", func(t *testing.T) {
+		other, _ := xuidsession.New(bytes.Repeat([]byte{8}, xuidsession.MinKeyLen), "sess")
+		token, _, _, _ := other.Issue(time.Hour)
+
+		_, _, err := issuer.Verify(token)
+
+		assert.ErrorIs(t, err, xuidsession.ErrInvalidSignature)
+	})
+
+	t.Run("rejects tokens with a swapped XUID", func(t *testing.T) {
+		token, _, _, _ := issuer.Issue(time.Hour)
+		_, tag, _ := strings.Cut(token, ".")
+
+		_, _, err := issuer.Verify(xuid.MustNewRandom("sess").String() + "." + tag)
+
+		assert.ErrorIs(t, err, xuidsession.ErrInvalidSignature)
+	})
+
+	t.Run("rejects malformed tokens", func(t *testing.T) {
+		id := xuid.MustNewRandom("sess").String()
+		for _, token := range []string{"", id, id + ".", id + ".!!!", id + ".AAAA"} {
+			_, _, err := issuer.Verify(token)
+
+			assert.ErrorIs(t, err, xuidsession.ErrMalformedToken, token)
+		}
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("reads token without verifying", func(t *testing.T) {
+		issuer, _ := xuidsession.New(testKey, "sess")
+		id := xuid.MustNewRandom("sess")
+		exp := time.Unix(1700000000, 0)
+
+		parsedID, parsedExp, err := xuidsession.Parse(issuer.Sign(id, exp))
+
+		require.NoError(t, err)
+		assert.True(t, id.Equal(parsedID))
+		assert.True(t, exp.Equal(parsedExp))
+	})
+}
@@ -0,0 +1,186 @@
+package xuidsql
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+)
+
+// MaxSequence is the largest sequence number an Allocator can encode.
+const MaxSequence = 1<<60 - 1
+
+var ErrSequenceExhausted = errors.New("sequence is exhausted")
+
+// Allocator issues XUIDs ordered by a per-prefix sequence kept in a
+// coordination table, for workloads needing stronger ordering across nodes
+// than UUIDv7 clocks provide. Each node reserves blocks of sequence numbers
+// in a transaction locking the prefix row (SELECT ... FOR UPDATE, or
+// UPDLOCK on SQL Server) and issues them from memory, so IDs of a node are
+// strictly increasing and IDs of different nodes are ordered by block.
+// Numbers left in a block when a node stops are never issued.
+//
+// The sequence number fills the first 60 bits of a UUIDv8, in place of the
+// timestamp of UUIDv7, followed by 62 random bits, so IDs sort by sequence
+// number. The table is created with AllocatorDDL.
+//
+// An Allocator is safe for concurrent use.
+type Allocator struct {
+	db        *sql.DB
+	dialect   xuid.Dialect
+	table     string
+	blockSize int
+
+	mu     sync.Mutex
+	blocks map[string]*block
+}
+
+// block is a range of reserved sequence numbers, from next to end excluded.
+type block struct {
+	next, end uint64
+}
+
+// NewAllocator returns an allocator reserving blocks of blockSize sequence
+// numbers, at least 1, from table in db.
+func NewAllocator(db *sql.DB, dialect xuid.Dialect, table string, blockSize int) *Allocator {
+	return &Allocator{db: db, dialect: dialect, table: table, blockSize: max(blockSize, 1), blocks: map[string]*block{}}
+}
+
+// AllocatorDDL returns the statement creating the coordination table of an
+// Allocator.
+func AllocatorDDL(dialect xuid.Dialect, table string) (string, error) {
+	prefix := fmt.Sprintf("varchar(%d)", xuid.MaxPrefixLen)
+	next := "bigint"
+	switch dialect {
+	case xuid.DialectPostgres, xuid.DialectPostgresText, xuid.DialectMySQL, xuid.DialectMySQLText, xuid.DialectMSSQL, xuid.DialectSQLite:
+	case xuid.DialectOracle:
+		prefix, next = fmt.Sprintf("VARCHAR2(%d)", xuid.MaxPrefixLen), "NUMBER(19)"
+	default:
+		return "", fmt.Errorf("unsupported SQL dialect %q", dialect)
+	}
+	return fmt.Sprintf("CREATE TABLE %s (prefix %s NOT NULL PRIMARY KEY, next_value %s NOT NULL)", table, prefix, next), nil
+}
+
+// New returns the next XUID with prefix.
+func (a *Allocator) New(ctx context.Context, prefix string) (xuid.XUID, error) {
+	ids, err := a.Reserve(ctx, prefix, 1)
+	if err != nil {
+		return xuid.XUID{}, err
+	}
+	return ids[0], nil
+}
+
+// Reserve returns the next n XUIDs with prefix, in increasing order.
+func (a *Allocator) Reserve(ctx context.Context, prefix string, n int) ([]xuid.XUID, error) {
+	if n < 0 {
+		return nil, xuid.ErrNegativeCount
+	}
+	if _, err := xuid.NewWith(uuid.Nil, prefix); err != nil {
+		return nil, err
+	}
+	random := make([]byte, 8*n)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	res := make([]xuid.XUID, 0, n)
+	for len(res) < n {
+		b := a.blocks[prefix]
+		if b == nil || b.next == b.end {
+			var err error
+			if b, err = a.reserveBlock(ctx, prefix, max(a.blockSize, n-len(res))); err != nil {
+				return nil, err
+			}
+			a.blocks[prefix] = b
+		}
+		for ; b.next < b.end && len(res) < n; b.next++ {
+			i := len(res)
+			id := sequenceUUID(b.next, random[8*i:8*i+8])
+			res = append(res, xuid.Must(xuid.NewWith(id, prefix)))
+		}
+	}
+	return res, nil
+}
+
+// reserveBlock reserves the next size sequence numbers of prefix in the
+// table, retrying once if another node created the prefix row concurrently.
+func (a *Allocator) reserveBlock(ctx context.Context, prefix string, size int) (*block, error) {
+	b, err := a.tryReserveBlock(ctx, prefix, size)
+	if errors.Is(err, errRowCreated) {
+		b, err = a.tryReserveBlock(ctx, prefix, size)
+	}
+	return b, err
+}
+
+// errRowCreated reports that creating a prefix row failed, presumably
+// because another node created it first.
+var errRowCreated = errors.New("prefix row could not be created")
+
+func (a *Allocator) tryReserveBlock(ctx context.Context, prefix string, size int) (*block, error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var next int64
+	err = tx.QueryRowContext(ctx, a.selectQuery(), prefix).Scan(&next)
+	exists := true
+	if errors.Is(err, sql.ErrNoRows) {
+		exists, err = false, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	end := uint64(next) + uint64(size)
+	if next < 0 || end > MaxSequence+1 {
+		return nil, fmt.Errorf("%w for prefix %q", ErrSequenceExhausted, prefix)
+	}
+	if exists {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET next_value = %s WHERE prefix = %s",
+			a.table, placeholder(a.dialect, 1), placeholder(a.dialect, 2)), int64(end), prefix)
+	} else {
+		_, err = tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (prefix, next_value) VALUES (%s, %s)",
+			a.table, placeholder(a.dialect, 1), placeholder(a.dialect, 2)), prefix, int64(end))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errRowCreated, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &block{next: uint64(next), end: end}, nil
+}
+
+func (a *Allocator) selectQuery() string {
+	switch a.dialect {
+	case xuid.DialectMSSQL:
+		return fmt.Sprintf("SELECT next_value FROM %s WITH (UPDLOCK, ROWLOCK) WHERE prefix = %s", a.table, placeholder(a.dialect, 1))
+	case xuid.DialectSQLite:
+		// SQLite locks the whole database for writing instead.
+		return fmt.Sprintf("SELECT next_value FROM %s WHERE prefix = %s", a.table, placeholder(a.dialect, 1))
+	}
+	return fmt.Sprintf("SELECT next_value FROM %s WHERE prefix = %s FOR UPDATE", a.table, placeholder(a.dialect, 1))
+}
+
+// sequenceUUID returns the UUIDv8 holding seq, laid out like the timestamp
+// and rand_a fields of UUIDv7, followed by random.
+func sequenceUUID(seq uint64, random []byte) uuid.UUID {
+	var id uuid.UUID
+	binary.BigEndian.PutUint64(id[:8], seq<<4)
+	binary.BigEndian.PutUint16(id[6:8], 0x8000|uint16(seq&0xfff)) // version 8
+	copy(id[8:], random)
+	id[8] = (id[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return id
+}
@@ -0,0 +1,190 @@
+package xuidsql_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidsql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seqDriver is a database/sql driver emulating the coordination table of
+// an Allocator in memory. It records the queries it runs.
+type seqDriver struct {
+	mu      sync.Mutex
+	rows    map[string]int64
+	queries []string
+}
+
+var seqDrivers = struct {
+	sync.Mutex
+	n int
+}{}
+
+func openSeqDB(t *testing.T) (*sql.DB, *seqDriver) {
+	d := &seqDriver{rows: map[string]int64{}}
+	seqDrivers.Lock()
+	seqDrivers.n++
+	name := "xuidseq" + string(rune('a'+seqDrivers.n))
+	seqDrivers.Unlock()
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+func (d *seqDriver) Open(string) (driver.Conn, error) { return seqConn{d}, nil }
+
+type seqConn struct{ d *seqDriver }
+
+func (c seqConn) Prepare(q string) (driver.Stmt, error) { return seqStmt{c.d, q}, nil }
+func (c seqConn) Close() error                          { return nil }
+func (c seqConn) Begin() (driver.Tx, error)             { return seqTx{}, nil }
+
+type seqTx struct{}
+
+func (seqTx) Commit() error   { return nil }
+func (seqTx) Rollback() error { return nil }
+
+type seqStmt struct {
+	d *seqDriver
+	q string
+}
+
+func (s seqStmt) Close() error  { return nil }
+func (s seqStmt) NumInput() int { return -1 }
+
+func (s seqStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.queries = append(s.d.queries, s.q)
+	switch {
+	case strings.HasPrefix(s.q, "UPDATE"):
+		s.d.rows[args[1].(string)] = args[0].(int64)
+	case strings.HasPrefix(s.q, "INSERT"):
+		if _, ok := s.d.rows[args[0].(string)]; ok {
+			return nil, errors.New("duplicate key")
+		}
+		s.d.rows[args[0].(string)] = args[1].(int64)
+	default:
+		return nil, errors.New("unexpected statement")
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s seqStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.queries = append(s.d.queries, s.q)
+	next, ok := s.d.rows[args[0].(string)]
+	return &seqRows{next: next, done: !ok}, nil
+}
+
+type seqRows struct {
+	next int64
+	done bool
+}
+
+func (r *seqRows) Columns() []string { return []string{"next_value"} }
+func (r *seqRows) Close() error      { return nil }
+
+func (r *seqRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.next
+	return nil
+}
+
+func TestAllocator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("issues increasing XUIDs", func(t *testing.T) {
+		db, _ := openSeqDB(t)
+		a := xuidsql.NewAllocator(db, xuid.DialectPostgres, "xuid_sequences", 4)
+
+		var ids []xuid.XUID
+		for i := 0; i < 10; i++ {
+			id, err := a.New(ctx, "invoice")
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		for i := 1; i < len(ids); i++ {
+			assert.Equal(t, -1, xuid.Compare(ids[i-1], ids[i]))
+		}
+		assert.Equal(t, "invoice", ids[0].GetPrefix())
+		assert.EqualValues(t, 8, ids[0].GetUUID().Version())
+	})
+
+	t.Run("reserves blocks from the table", func(t *testing.T) {
+		db, d := openSeqDB(t)
+		a := xuidsql.NewAllocator(db, xuid.DialectPostgres, "xuid_sequences", 100)
+
+		_, err := a.Reserve(ctx, "invoice", 150)
+		require.NoError(t, err)
+		_, err = a.New(ctx, "invoice")
+		require.NoError(t, err)
+
+		assert.EqualValues(t, 250, d.rows["invoice"])
+		assert.Equal(t, "SELECT next_value FROM xuid_sequences WHERE prefix = $1 FOR UPDATE", d.queries[0])
+	})
+
+	t.Run("orders IDs of different nodes by block", func(t *testing.T) {
+		db, _ := openSeqDB(t)
+		a1 := xuidsql.NewAllocator(db, xuid.DialectMySQL, "xuid_sequences", 10)
+		a2 := xuidsql.NewAllocator(db, xuid.DialectMySQL, "xuid_sequences", 10)
+
+		first, err := a1.New(ctx, "invoice")
+		require.NoError(t, err)
+		second, err := a2.New(ctx, "invoice")
+		require.NoError(t, err)
+		third, err := a1.New(ctx, "invoice")
+		require.NoError(t, err)
+
+		assert.Equal(t, -1, xuid.Compare(first, third))
+		assert.Equal(t, -1, xuid.Compare(third, second))
+	})
+
+	t.Run("keeps prefixes separate", func(t *testing.T) {
+		db, d := openSeqDB(t)
+		a := xuidsql.NewAllocator(db, xuid.DialectSQLite, "xuid_sequences", 5)
+
+		_, err := a.New(ctx, "invoice")
+		require.NoError(t, err)
+		_, err = a.New(ctx, "order")
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]int64{"invoice": 5, "order": 5}, d.rows)
+	})
+
+	t.Run("rejects negative counts", func(t *testing.T) {
+		db, d := openSeqDB(t)
+		a := xuidsql.NewAllocator(db, xuid.DialectPostgres, "xuid_sequences", 5)
+
+		_, err := a.Reserve(ctx, "invoice", -1)
+
+		assert.ErrorIs(t, err, xuid.ErrNegativeCount)
+		assert.Empty(t, d.queries)
+	})
+}
+
+func TestAllocatorDDL(t *testing.T) {
+	ddl, err := xuidsql.AllocatorDDL(xuid.DialectPostgres, "xuid_sequences")
+
+	require.NoError(t, err)
+	assert.Equal(t, "CREATE TABLE xuid_sequences (prefix varchar(128) NOT NULL PRIMARY KEY, next_value bigint NOT NULL)", ddl)
+
+	_, err = xuidsql.AllocatorDDL("db2", "xuid_sequences")
+	assert.Error(t, err)
+}
@@ -0,0 +1,161 @@
+package xuidsql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidDest is xuid.ErrInvalidDest, returned by RestorePrefixes for
+// destinations other than pointers to structs or slices of structs.
+var ErrInvalidDest = xuid.ErrInvalidDest
+
+// prefixSetter is implemented by *xuid.XUID and by pointers to types
+// embedding xuid.XUID, such as xuidorm.ID.
+type prefixSetter interface {
+	SetPrefix(prefix string) *xuid.XUID
+	GetUUID() uuid.UUID
+}
+
+var prefixSetterType = reflect.TypeOf((*prefixSetter)(nil)).Elem()
+
+// prefixField is the index path of a XUID field and its tag prefix.
+type prefixField struct {
+	index  []int
+	prefix string
+	ptr    bool
+}
+
+// plans caches the prefix fields of struct types.
+var plans sync.Map // map[reflect.Type][]prefixField
+
+// RestorePrefixes sets the prefix of XUID fields tagged with a prefix
+// option, such as `db:"id,prefix=user"`, after rows were scanned into dest,
+// which restores the prefix lost by Scan. dest is a pointer to a struct or
+// to a slice of structs or struct pointers, as passed to sqlx StructScan,
+// Get and Select, which ignore the option:
+//
+//	var users []User
+//	err := db.Select(&users, "SELECT * FROM users")
+//	err = xuidsql.RestorePrefixes(&users)
+//
+// Fields of embedded structs are included. Nil XUIDs and nil pointers are
+// left unchanged. Fields with xuid tags, such as `xuid:"prefix=user"`, are
+// then checked and completed by xuid.ApplyTags.
+func RestorePrefixes(dest any) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return ErrInvalidDest
+	}
+	v = v.Elem()
+	switch v.Kind() {
+	case reflect.Struct:
+		restore(v)
+		return xuid.ApplyTags(dest)
+	case reflect.Slice:
+		elem := v.Type().Elem()
+		if elem.Kind() != reflect.Struct && (elem.Kind() != reflect.Pointer || elem.Elem().Kind() != reflect.Struct) {
+			return fmt.Errorf("%w: want a pointer to a struct or a slice of structs, got %T", ErrInvalidDest, dest)
+		}
+		for i := 0; i < v.Len(); i++ {
+			e := v.Index(i)
+			if e.Kind() == reflect.Pointer {
+				if e.IsNil() {
+					continue
+				}
+				e = e.Elem()
+			}
+			restore(e)
+		}
+		return xuid.ApplyTags(dest)
+	}
+	return fmt.Errorf("%w: want a pointer to a struct or a slice of structs, got %T", ErrInvalidDest, dest)
+}
+
+func restore(v reflect.Value) {
+	for _, f := range planFor(v.Type()) {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok {
+			continue
+		}
+		if f.ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		s := fv.Addr().Interface().(prefixSetter)
+		if s.GetUUID() != uuid.Nil {
+			s.SetPrefix(f.prefix)
+		}
+	}
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but reports false instead
+// of panicking on nil embedded pointers.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func planFor(t reflect.Type) []prefixField {
+	if p, ok := plans.Load(t); ok {
+		return p.([]prefixField)
+	}
+	p := buildPlan(t, nil)
+	plans.Store(t, p)
+	return p
+}
+
+func buildPlan(t reflect.Type, parent []int) []prefixField {
+	var res []prefixField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		index := append(append([]int(nil), parent...), i)
+		if prefix, ok := tagPrefix(sf.Tag.Get("db")); ok && sf.IsExported() {
+			switch {
+			case reflect.PointerTo(sf.Type).Implements(prefixSetterType):
+				res = append(res, prefixField{index: index, prefix: prefix})
+				continue
+			case sf.Type.Kind() == reflect.Pointer && sf.Type.Implements(prefixSetterType):
+				res = append(res, prefixField{index: index, prefix: prefix, ptr: true})
+				continue
+			}
+		}
+		if sf.Anonymous {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				res = append(res, buildPlan(ft, index)...)
+			}
+		}
+	}
+	return res
+}
+
+// tagPrefix returns the value of the prefix option of a db tag.
+func tagPrefix(tag string) (string, bool) {
+	_, opts, _ := strings.Cut(tag, ",")
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if p, ok := strings.CutPrefix(opt, "prefix="); ok {
+			return p, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,87 @@
+package xuidsql_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidorm"
+	"github.com/47monad/xuid/xuidsql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type base struct {
+	ID xuid.XUID `db:"id,prefix=user"`
+}
+
+type user struct {
+	base
+	OrgID   *xuid.XUID `db:"org_id,prefix=org"`
+	TeamID  xuidorm.ID `db:"team_id,prefix=team"`
+	OtherID xuid.XUID  `db:"other_id"`
+	Name    string     `db:"name,prefix=ignored"`
+}
+
+// scanned returns a user as scanned from a database, without prefixes.
+func scanned(t *testing.T) user {
+	var u user
+	for _, x := range []*xuid.XUID{&u.ID, &u.TeamID.XUID, &u.OtherID} {
+		require.NoError(t, x.Scan(xuid.MustNewSortable("").GetUUID().String()))
+	}
+	org := xuid.MustNewSortable("")
+	u.OrgID = &org
+	return u
+}
+
+func TestRestorePrefixes(t *testing.T) {
+	t.Run("restores tagged prefixes of a struct", func(t *testing.T) {
+		u := scanned(t)
+
+		require.NoError(t, xuidsql.RestorePrefixes(&u))
+
+		assert.Equal(t, "user", u.ID.GetPrefix())
+		assert.Equal(t, "org", u.OrgID.GetPrefix())
+		assert.Equal(t, "team", u.TeamID.GetPrefix())
+		assert.Equal(t, "", u.OtherID.GetPrefix())
+	})
+
+	t.Run("restores prefixes of slices", func(t *testing.T) {
+		users := []user{scanned(t), scanned(t)}
+		u := scanned(t)
+		ptrs := []*user{&u, nil}
+
+		require.NoError(t, xuidsql.RestorePrefixes(&users))
+		require.NoError(t, xuidsql.RestorePrefixes(&ptrs))
+
+		assert.Equal(t, "user", users[1].ID.GetPrefix())
+		assert.Equal(t, "user", u.ID.GetPrefix())
+	})
+
+	t.Run("leaves nil XUIDs unchanged", func(t *testing.T) {
+		var u user
+
+		require.NoError(t, xuidsql.RestorePrefixes(&u))
+
+		assert.Equal(t, xuid.XUID{}, u.ID)
+		assert.Nil(t, u.OrgID)
+	})
+
+	t.Run("applies xuid tags", func(t *testing.T) {
+		var m struct {
+			UserID xuid.XUID `db:"user_id" xuid:"prefix=user"`
+			OrgID  xuid.XUID `db:"org_id" xuid:"prefix=org,require"`
+		}
+		require.NoError(t, m.UserID.Scan(xuid.MustNewSortable("").GetUUID().String()))
+
+		err := xuidsql.RestorePrefixes(&m)
+
+		assert.ErrorIs(t, err, xuid.ErrMissingXUID)
+		assert.Equal(t, "user", m.UserID.GetPrefix())
+	})
+
+	t.Run("rejects other destinations", func(t *testing.T) {
+		for _, dest := range []any{user{}, (*user)(nil), &[]int{}, new(int)} {
+			assert.ErrorIs(t, xuidsql.RestorePrefixes(dest), xuidsql.ErrInvalidDest)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+// Package xuidsql builds SQL fragments and arguments for XUIDs, for lists
+// of IDs and for schemas storing the prefix in its own column.
+package xuidsql
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/47monad/xuid"
+)
+
+// MaxParams returns the maximum number of IN list parameters per statement
+// supported by dialect: the bind parameter limit of the database, or the
+// IN list limit of Oracle. Use it as the chunk size of Chunks.
+func MaxParams(dialect xuid.Dialect) int {
+	switch dialect {
+	case xuid.DialectMSSQL:
+		return 2100
+	case xuid.DialectOracle:
+		return 1000
+	case xuid.DialectSQLite:
+		return 32766
+	}
+	return 65535
+}
+
+// InClause returns a parenthesized list of placeholders for ids, such as
+// "($1,$2,$3)" for DialectPostgres or "(?,?,?)" for DialectMySQL, and the
+// matching arguments. Placeholders are numbered from 1; use InClauseAt when
+// the query has other numbered parameters before the list.
+//
+// Arguments are the XUIDs, stored through their Value method, except for
+// text dialects, which store the full XUID string. An empty ids yields
+// "(NULL)", which matches no rows.
+func InClause(ids []xuid.XUID, dialect xuid.Dialect) (string, []any) {
+	return InClauseAt(ids, dialect, 1)
+}
+
+// InClauseAt is like InClause but numbers placeholders from start, for
+// dialects with numbered placeholders.
+func InClauseAt(ids []xuid.XUID, dialect xuid.Dialect, start int) (string, []any) {
+	if len(ids) == 0 {
+		return "(NULL)", nil
+	}
+	var b strings.Builder
+	args := make([]any, len(ids))
+	b.WriteByte('(')
+	for i, x := range ids {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(placeholder(dialect, start+i))
+		if dialect == xuid.DialectPostgresText || dialect == xuid.DialectMySQLText {
+			args[i] = x.String()
+		} else {
+			args[i] = x
+		}
+	}
+	b.WriteByte(')')
+	return b.String(), args
+}
+
+// Chunks splits ids into consecutive chunks of at most size XUIDs, which
+// share the backing array of ids. A size below 1 is treated as 1.
+func Chunks(ids []xuid.XUID, size int) [][]xuid.XUID {
+	size = max(size, 1)
+	res := make([][]xuid.XUID, 0, (len(ids)+size-1)/size)
+	for len(ids) > size {
+		res = append(res, ids[:size:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		res = append(res, ids)
+	}
+	return res
+}
+
+func placeholder(dialect xuid.Dialect, n int) string {
+	switch dialect {
+	case xuid.DialectPostgres, xuid.DialectPostgresText:
+		return "$" + strconv.Itoa(n)
+	case xuid.DialectMSSQL:
+		return "@p" + strconv.Itoa(n)
+	case xuid.DialectOracle:
+		return ":" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// SplitArgs returns the arguments storing x in a prefix column and a UUID
+// column, in that order, such as for
+// "INSERT INTO users (id_prefix, id) VALUES ($1, $2)". Both are NULL for a
+// nil XUID.
+func SplitArgs(x xuid.XUID) []any {
+	if xuid.IsEmpty(x) {
+		return []any{nil, nil}
+	}
+	prefix, _ := x.SplitForStorage()
+	return []any{prefix, x}
+}
+
+// SplitColumns scans a XUID stored in a prefix column and a UUID column,
+// restoring its prefix:
+//
+//	var id xuidsql.SplitColumns
+//	err := row.Scan(append(id.Dest(), &name)...)
+//	user, err := id.XUID()
+type SplitColumns struct {
+	prefix sql.NullString
+	id     xuid.XUID
+}
+
+// Dest returns the scan destinations of the prefix and UUID columns.
+func (s *SplitColumns) Dest() []any {
+	return []any{&s.prefix, &s.id}
+}
+
+// XUID returns the scanned XUID, or a nil XUID if the columns were NULL.
+func (s *SplitColumns) XUID() (xuid.XUID, error) {
+	if xuid.IsEmpty(s.id) {
+		return xuid.XUID{}, nil
+	}
+	return xuid.JoinFromStorage(s.prefix.String, s.id.GetUUID())
+}
@@ -0,0 +1,119 @@
+package xuidsql_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidsql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInClause(t *testing.T) {
+	ids := []xuid.XUID{xuid.MustNewSortable("user"), xuid.MustNewSortable("user"), xuid.MustNewSortable("user")}
+
+	t.Run("formats placeholders per dialect", func(t *testing.T) {
+		tests := map[xuid.Dialect]string{
+			xuid.DialectPostgres: "($1,$2,$3)",
+			xuid.DialectMySQL:    "(?,?,?)",
+			xuid.DialectSQLite:   "(?,?,?)",
+			xuid.DialectMSSQL:    "(@p1,@p2,@p3)",
+			xuid.DialectOracle:   "(:1,:2,:3)",
+		}
+		for dialect, want := range tests {
+			clause, args := xuidsql.InClause(ids, dialect)
+
+			assert.Equal(t, want, clause, dialect)
+			assert.Equal(t, []any{ids[0], ids[1], ids[2]}, args, dialect)
+		}
+	})
+
+	t.Run("numbers placeholders from start", func(t *testing.T) {
+		clause, _ := xuidsql.InClauseAt(ids[:2], xuid.DialectPostgres, 3)
+
+		assert.Equal(t, "($3,$4)", clause)
+	})
+
+	t.Run("passes strings to text dialects", func(t *testing.T) {
+		_, args := xuidsql.InClause(ids[:1], xuid.DialectPostgresText)
+
+		assert.Equal(t, []any{ids[0].String()}, args)
+	})
+
+	t.Run("matches nothing for empty lists", func(t *testing.T) {
+		clause, args := xuidsql.InClause(nil, xuid.DialectPostgres)
+
+		assert.Equal(t, "(NULL)", clause)
+		assert.Empty(t, args)
+	})
+}
+
+func TestChunks(t *testing.T) {
+	ids := make([]xuid.XUID, 5)
+	for i := range ids {
+		ids[i] = xuid.MustNewSortable("user")
+	}
+
+	t.Run("splits into chunks of at most size", func(t *testing.T) {
+		chunks := xuidsql.Chunks(ids, 2)
+
+		assert.Equal(t, [][]xuid.XUID{ids[0:2], ids[2:4], ids[4:5]}, chunks)
+	})
+
+	t.Run("keeps exact multiples in full chunks", func(t *testing.T) {
+		assert.Len(t, xuidsql.Chunks(ids[:4], 2), 2)
+		assert.Len(t, xuidsql.Chunks(ids, 5), 1)
+	})
+
+	t.Run("returns no chunks for empty lists", func(t *testing.T) {
+		assert.Empty(t, xuidsql.Chunks(nil, 10))
+	})
+
+	t.Run("appending to a chunk does not overwrite the next", func(t *testing.T) {
+		chunks := xuidsql.Chunks(ids, 2)
+		next := chunks[1][0]
+
+		_ = append(chunks[0], xuid.MustNewSortable("user"))
+
+		assert.Equal(t, next, chunks[1][0])
+	})
+}
+
+func TestMaxParams(t *testing.T) {
+	assert.Equal(t, 2100, xuidsql.MaxParams(xuid.DialectMSSQL))
+	assert.Equal(t, 1000, xuidsql.MaxParams(xuid.DialectOracle))
+	assert.Equal(t, 65535, xuidsql.MaxParams(xuid.DialectPostgres))
+}
+
+func TestSplitColumns(t *testing.T) {
+	t.Run("round-trips through split arguments", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+		args := xuidsql.SplitArgs(x)
+		uuidValue, err := args[1].(driver.Valuer).Value()
+		require.NoError(t, err)
+
+		var cols xuidsql.SplitColumns
+		dest := cols.Dest()
+		require.NoError(t, dest[0].(sql.Scanner).Scan(args[0]))
+		require.NoError(t, dest[1].(sql.Scanner).Scan(uuidValue))
+		got, err := cols.XUID()
+
+		require.NoError(t, err)
+		assert.Equal(t, x, got)
+	})
+
+	t.Run("stores nil XUIDs as NULL", func(t *testing.T) {
+		assert.Equal(t, []any{nil, nil}, xuidsql.SplitArgs(xuid.XUID{}))
+
+		var cols xuidsql.SplitColumns
+		for _, d := range cols.Dest() {
+			require.NoError(t, d.(sql.Scanner).Scan(nil))
+		}
+		got, err := cols.XUID()
+
+		require.NoError(t, err)
+		assert.True(t, xuid.IsEmpty(got))
+	})
+}
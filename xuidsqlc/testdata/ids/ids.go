@@ -0,0 +1,22 @@
+// Package ids declares the ID types referenced by sqlc.yaml.
+package ids
+
+import "github.com/47monad/xuid/xuidsqlc"
+
+type UserPrefix struct{}
+
+func (UserPrefix) Prefix() string { return "user" }
+
+type OrderPrefix struct{}
+
+func (OrderPrefix) Prefix() string { return "order" }
+
+type CouponPrefix struct{}
+
+func (CouponPrefix) Prefix() string { return "coupon" }
+
+type (
+	UserID   = xuidsqlc.ID[UserPrefix]
+	OrderID  = xuidsqlc.ID[OrderPrefix]
+	CouponID = xuidsqlc.ID[CouponPrefix]
+)
@@ -0,0 +1,12 @@
+-- name: GetUser :one
+SELECT id, name FROM users
+WHERE id = $1;
+
+-- name: CreateUser :exec
+INSERT INTO users (id, name)
+VALUES ($1, $2);
+
+-- name: ListOrdersByUser :many
+SELECT id, user_id, coupon_id FROM orders
+WHERE user_id = $1
+ORDER BY id;
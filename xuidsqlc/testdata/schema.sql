@@ -0,0 +1,10 @@
+CREATE TABLE users (
+    id   uuid PRIMARY KEY,
+    name text NOT NULL
+);
+
+CREATE TABLE orders (
+    id        uuid PRIMARY KEY,
+    user_id   uuid NOT NULL REFERENCES users (id),
+    coupon_id uuid
+);
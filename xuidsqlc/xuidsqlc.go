@@ -0,0 +1,76 @@
+// Package xuidsqlc provides column types for sqlc type overrides.
+//
+// sqlc overrides map a column or database type to a named Go type, which
+// must scan and store itself. ID restores the prefix of its XUID on Scan,
+// with the prefix given by a type parameter, so each entity declares its
+// column type once:
+//
+//	type UserPrefix struct{}
+//
+//	func (UserPrefix) Prefix() string { return "user" }
+//
+//	type UserID = xuidsqlc.ID[UserPrefix]
+//
+// and sqlc.yaml refers to it:
+//
+//	overrides:
+//	  - column: "users.id"
+//	    go_type:
+//	      import: "example.com/app/ids"
+//	      type: "UserID"
+//
+// testdata contains a complete schema, queries and configuration, and the
+// code sqlc generates from them, which the tests compile.
+package xuidsqlc
+
+import (
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/47monad/xuid"
+)
+
+// Prefix is implemented by the empty struct types naming the prefix of an
+// ID type.
+type Prefix interface {
+	Prefix() string
+}
+
+var (
+	_ driver.Valuer = ID[Prefix]{}
+	_ sql.Scanner   = (*ID[Prefix])(nil)
+)
+
+// ID is a XUID column whose prefix is restored from P on Scan.
+// All XUID methods, including Value, String and JSON marshaling, are
+// promoted.
+type ID[P Prefix] struct {
+	xuid.XUID
+}
+
+// New returns a new sortable ID with the prefix of P.
+func New[P Prefix]() (ID[P], error) {
+	var p P
+	x, err := xuid.NewSortable(p.Prefix())
+	return ID[P]{XUID: x}, err
+}
+
+// Wrap returns x as an ID, replacing its prefix with the prefix of P.
+func Wrap[P Prefix](x xuid.XUID) ID[P] {
+	var p P
+	x.SetPrefix(p.Prefix())
+	return ID[P]{XUID: x}
+}
+
+// Scan implements the sql.Scanner interface. NULL scans to a nil XUID
+// without prefix.
+func (id *ID[P]) Scan(value interface{}) error {
+	if err := id.XUID.Scan(value); err != nil {
+		return err
+	}
+	if !xuid.IsEmpty(id.XUID) {
+		var p P
+		id.SetPrefix(p.Prefix())
+	}
+	return nil
+}
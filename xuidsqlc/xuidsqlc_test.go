@@ -0,0 +1,87 @@
+package xuidsqlc_test
+
+import (
+	"encoding/json"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidsqlc"
+	"github.com/47monad/xuid/xuidsqlc/testdata/db"
+	"github.com/47monad/xuid/xuidsqlc/testdata/ids"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type UserPrefix struct{}
+
+func (UserPrefix) Prefix() string { return "user" }
+
+type UserID = xuidsqlc.ID[UserPrefix]
+
+func TestID(t *testing.T) {
+	t.Run("creates IDs with the prefix of P", func(t *testing.T) {
+		id, err := xuidsqlc.New[UserPrefix]()
+
+		require.NoError(t, err)
+		assert.True(t, id.IsSortable())
+		assert.Equal(t, "user", id.GetPrefix())
+	})
+
+	t.Run("restores the prefix on Scan", func(t *testing.T) {
+		user, coupon := xuid.MustNewSortable("user"), xuid.MustNewSortable("coupon")
+		uv, err := user.Value()
+		require.NoError(t, err)
+		cv, err := coupon.Value()
+		require.NoError(t, err)
+
+		var o db.Order
+		o.CouponID = new(ids.CouponID)
+		require.NoError(t, o.UserID.Scan(uv))
+		require.NoError(t, o.CouponID.Scan(cv))
+
+		assert.Equal(t, user, o.UserID.XUID)
+		assert.Equal(t, coupon, o.CouponID.XUID)
+	})
+
+	t.Run("scans NULL without prefix", func(t *testing.T) {
+		id := xuidsqlc.Wrap[UserPrefix](xuid.MustNewSortable(""))
+
+		require.NoError(t, id.Scan(nil))
+
+		assert.Equal(t, xuid.XUID{}, id.XUID)
+	})
+
+	t.Run("wraps XUIDs with the prefix of P", func(t *testing.T) {
+		x := xuid.MustNewSortable("")
+
+		id := xuidsqlc.Wrap[UserPrefix](x)
+
+		assert.Equal(t, x.GetUUID(), id.GetUUID())
+		assert.Equal(t, "user", id.GetPrefix())
+	})
+
+	t.Run("marshals to JSON as a XUID string", func(t *testing.T) {
+		id, _ := xuidsqlc.New[UserPrefix]()
+
+		data, err := json.Marshal(id)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `"`+id.String()+`"`, string(data))
+	})
+}
+
+func TestSQLCConfig(t *testing.T) {
+	t.Run("imports the ID types of testdata", func(t *testing.T) {
+		conf, err := os.ReadFile("testdata/sqlc.yaml")
+		require.NoError(t, err)
+
+		imports := regexp.MustCompile(`import: "(.*)"`).FindAllStringSubmatch(string(conf), -1)
+
+		require.NotEmpty(t, imports)
+		for _, m := range imports {
+			assert.Equal(t, "github.com/47monad/xuid/xuidsqlc/testdata/ids", m[1])
+		}
+	})
+}
@@ -0,0 +1,92 @@
+// Package xuidtemporal derives Temporal workflow IDs from XUIDs.
+//
+// Workflow IDs have the form "WorkflowType/prefix_payload", so every
+// workflow type gets its own ID space and starting the same workflow for
+// the same entity twice is rejected by Temporal instead of colliding with
+// another workflow type.
+package xuidtemporal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+)
+
+// MaxIDLen is the default maximum length of workflow IDs accepted by the
+// Temporal server.
+const MaxIDLen = 1000
+
+const sep = "/"
+
+var (
+	ErrInvalidWorkflowType = errors.New("workflow type is empty or contains " + sep)
+	ErrInvalidPrefix       = errors.New("XUID prefix contains " + sep)
+	ErrIDTooLong           = errors.New("workflow ID is longer than MaxIDLen")
+	ErrInvalidWorkflowID   = errors.New("workflow ID was not created by WorkflowID")
+	ErrInvalidRunID        = errors.New("run ID is not a UUID")
+)
+
+// WorkflowID returns the workflow ID of the workflow of type workflowType
+// for the entity identified by x. Neither the workflow type nor the prefix
+// of x may contain a slash.
+func WorkflowID(workflowType string, x xuid.XUID) (string, error) {
+	if workflowType == "" || strings.Contains(workflowType, sep) {
+		return "", ErrInvalidWorkflowType
+	}
+	if strings.Contains(x.GetPrefix(), sep) {
+		return "", ErrInvalidPrefix
+	}
+	id := workflowType + sep + x.String()
+	if len(id) > MaxIDLen {
+		return "", ErrIDTooLong
+	}
+	return id, nil
+}
+
+// ChildWorkflowID returns the ID of the child workflow of type
+// workflowType started by the workflow with ID parent for the entity
+// identified by x, such as "OrderWorkflow/order_.../ShipmentWorkflow/ship_...".
+func ChildWorkflowID(parent, workflowType string, x xuid.XUID) (string, error) {
+	child, err := WorkflowID(workflowType, x)
+	if err != nil {
+		return "", err
+	}
+	id := parent + sep + child
+	if len(id) > MaxIDLen {
+		return "", ErrIDTooLong
+	}
+	return id, nil
+}
+
+// ParseWorkflowID returns the workflow type and XUID of a workflow ID
+// created by WorkflowID. For child workflow IDs, it returns the innermost
+// workflow type and XUID.
+func ParseWorkflowID(id string) (string, xuid.XUID, error) {
+	i := strings.LastIndex(id, sep)
+	if i < 0 {
+		return "", xuid.XUID{}, ErrInvalidWorkflowID
+	}
+	j := strings.LastIndex(id[:i], sep)
+	workflowType := id[j+1 : i]
+	if workflowType == "" {
+		return "", xuid.XUID{}, ErrInvalidWorkflowID
+	}
+	x, err := xuid.Parse(id[i+1:])
+	if err != nil {
+		return "", xuid.XUID{}, fmt.Errorf("%w: %w", ErrInvalidWorkflowID, err)
+	}
+	return workflowType, x, nil
+}
+
+// RunID converts the run ID that Temporal assigns to each workflow run,
+// which is a UUID string, to a XUID with the given prefix.
+func RunID(runID, prefix string) (xuid.XUID, error) {
+	id, err := uuid.Parse(runID)
+	if err != nil {
+		return xuid.XUID{}, fmt.Errorf("%w: %w", ErrInvalidRunID, err)
+	}
+	return xuid.NewWith(id, prefix)
+}
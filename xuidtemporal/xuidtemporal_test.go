@@ -0,0 +1,115 @@
+package xuidtemporal_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidtemporal"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowID(t *testing.T) {
+	t.Run("namespaces ID by workflow type", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+
+		id, err := xuidtemporal.WorkflowID("OnboardUser", x)
+
+		require.NoError(t, err)
+		assert.Equal(t, "OnboardUser/"+x.String(), id)
+	})
+
+	t.Run("is deterministic", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+
+		a, _ := xuidtemporal.WorkflowID("OnboardUser", x)
+		b, _ := xuidtemporal.WorkflowID("OnboardUser", x)
+		c, _ := xuidtemporal.WorkflowID("DeleteUser", x)
+
+		assert.Equal(t, a, b)
+		assert.NotEqual(t, a, c)
+	})
+
+	t.Run("rejects invalid workflow types", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+
+		for _, wt := range []string{"", "a/b"} {
+			_, err := xuidtemporal.WorkflowID(wt, x)
+
+			assert.ErrorIs(t, err, xuidtemporal.ErrInvalidWorkflowType)
+		}
+	})
+
+	t.Run("rejects prefixes with slashes", func(t *testing.T) {
+		_, err := xuidtemporal.WorkflowID("OnboardUser", xuid.MustNewSortable("org/user"))
+
+		assert.ErrorIs(t, err, xuidtemporal.ErrInvalidPrefix)
+	})
+
+	t.Run("rejects IDs longer than MaxIDLen", func(t *testing.T) {
+		_, err := xuidtemporal.WorkflowID(strings.Repeat("W", xuidtemporal.MaxIDLen), xuid.MustNewSortable("user"))
+
+		assert.ErrorIs(t, err, xuidtemporal.ErrIDTooLong)
+	})
+}
+
+func TestChildWorkflowID(t *testing.T) {
+	order := xuid.MustNewSortable("order")
+	shipment := xuid.MustNewSortable("ship")
+	parent, err := xuidtemporal.WorkflowID("OrderWorkflow", order)
+	require.NoError(t, err)
+
+	id, err := xuidtemporal.ChildWorkflowID(parent, "ShipmentWorkflow", shipment)
+
+	require.NoError(t, err)
+	assert.Equal(t, parent+"/ShipmentWorkflow/"+shipment.String(), id)
+
+	workflowType, x, err := xuidtemporal.ParseWorkflowID(id)
+	require.NoError(t, err)
+	assert.Equal(t, "ShipmentWorkflow", workflowType)
+	assert.True(t, shipment.Equal(x))
+
+	_, err = xuidtemporal.ChildWorkflowID(strings.Repeat("p", xuidtemporal.MaxIDLen), "ShipmentWorkflow", shipment)
+	assert.ErrorIs(t, err, xuidtemporal.ErrIDTooLong)
+}
+
+func TestParseWorkflowID(t *testing.T) {
+	t.Run("round-trips", func(t *testing.T) {
+		for _, prefix := range []string{"user", "user_profile", ""} {
+			x := xuid.Must(xuid.NewSortable(prefix))
+			id, err := xuidtemporal.WorkflowID("OnboardUser", x)
+			require.NoError(t, err)
+
+			workflowType, parsed, err := xuidtemporal.ParseWorkflowID(id)
+
+			require.NoError(t, err)
+			assert.Equal(t, "OnboardUser", workflowType)
+			assert.True(t, x.Equal(parsed))
+		}
+	})
+
+	t.Run("rejects other IDs", func(t *testing.T) {
+		x := xuid.MustNewSortable("user")
+
+		for _, id := range []string{"", x.String(), "/" + x.String(), "OnboardUser/user_0OIl"} {
+			_, _, err := xuidtemporal.ParseWorkflowID(id)
+
+			assert.ErrorIs(t, err, xuidtemporal.ErrInvalidWorkflowID, id)
+		}
+	})
+}
+
+func TestRunID(t *testing.T) {
+	runID := uuid.NewString()
+
+	x, err := xuidtemporal.RunID(runID, "run")
+
+	require.NoError(t, err)
+	assert.Equal(t, runID, x.GetUUID().String())
+	assert.Equal(t, "run", x.GetPrefix())
+
+	_, err = xuidtemporal.RunID("not-a-uuid", "run")
+	assert.ErrorIs(t, err, xuidtemporal.ErrInvalidRunID)
+}
@@ -0,0 +1,112 @@
+package xuidtest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// VerifyCodecConsistency fails t unless x round-trips to an equal value
+// through every representation its type supports: JSON, and text, binary
+// and SQL values when T and *T implement the matching marshaling and
+// unmarshaling interfaces. Types embedding xuid.XUID, such as typed IDs or
+// nullable wrappers, can thus be checked with one call:
+//
+//	xuidtest.VerifyCodecConsistency(t, ids.UserID{XUID: xuid.MustNewSortable("user")})
+//
+// Values are compared with an Equal(T) bool method if T has one, and with
+// reflect.DeepEqual otherwise. SQL values do not store the prefix, so for
+// types with a GetUUID method only the UUIDs are compared after Scan.
+func VerifyCodecConsistency[T any](t testing.TB, x T) {
+	t.Helper()
+
+	data, err := json.Marshal(x)
+	if err != nil {
+		t.Errorf("xuidtest: JSON marshal %v: %v", x, err)
+	} else {
+		var got T
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Errorf("xuidtest: JSON unmarshal %s: %v", data, err)
+		} else if !equal(x, got) {
+			t.Errorf("xuidtest: JSON round-trip of %v gave %v", x, got)
+		}
+	}
+
+	if m, ok := any(x).(encoding.TextMarshaler); ok {
+		var got T
+		if u, ok := any(&got).(encoding.TextUnmarshaler); ok {
+			verifyRoundTrip(t, "text", x, &got, m.MarshalText, u.UnmarshalText)
+		}
+	}
+
+	if m, ok := any(x).(encoding.BinaryMarshaler); ok {
+		var got T
+		if u, ok := any(&got).(encoding.BinaryUnmarshaler); ok {
+			verifyRoundTrip(t, "binary", x, &got, m.MarshalBinary, u.UnmarshalBinary)
+		}
+	}
+
+	if v, ok := any(x).(driver.Valuer); ok {
+		var got T
+		if s, ok := any(&got).(sql.Scanner); ok {
+			verifySQL(t, x, &got, v, s)
+		}
+	}
+}
+
+func verifyRoundTrip[T any](t testing.TB, codec string, x T, got *T, marshal func() ([]byte, error), unmarshal func([]byte) error) {
+	t.Helper()
+	data, err := marshal()
+	if err != nil {
+		t.Errorf("xuidtest: %s marshal %v: %v", codec, x, err)
+		return
+	}
+	if err := unmarshal(data); err != nil {
+		t.Errorf("xuidtest: %s unmarshal %q: %v", codec, data, err)
+		return
+	}
+	if !equal(x, *got) {
+		t.Errorf("xuidtest: %s round-trip of %v gave %v", codec, x, *got)
+	}
+}
+
+func verifySQL[T any](t testing.TB, x T, got *T, v driver.Valuer, s sql.Scanner) {
+	t.Helper()
+	value, err := v.Value()
+	if err != nil {
+		t.Errorf("xuidtest: SQL value of %v: %v", x, err)
+		return
+	}
+	if !driver.IsValue(value) {
+		t.Errorf("xuidtest: SQL value of %v has unsupported type %T", x, value)
+		return
+	}
+	if err := s.Scan(value); err != nil {
+		t.Errorf("xuidtest: SQL scan %v: %v", value, err)
+		return
+	}
+	type uuider interface{ GetUUID() uuid.UUID }
+	xu, ok1 := any(x).(uuider)
+	gu, ok2 := any(*got).(uuider)
+	if ok1 && ok2 {
+		if xu.GetUUID() != gu.GetUUID() {
+			t.Errorf("xuidtest: SQL round-trip of %v gave UUID %v", x, gu.GetUUID())
+		}
+		return
+	}
+	if !equal(x, *got) {
+		t.Errorf("xuidtest: SQL round-trip of %v gave %v", x, *got)
+	}
+}
+
+func equal[T any](x, y T) bool {
+	if e, ok := any(x).(interface{ Equal(T) bool }); ok {
+		return e.Equal(y)
+	}
+	return reflect.DeepEqual(x, y)
+}
@@ -0,0 +1,67 @@
+package xuidtest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidorm"
+	"github.com/47monad/xuid/xuidtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a testing.TB recording errors instead of failing.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// lossyID drops its prefix when unmarshaled from text.
+type lossyID struct {
+	xuid.XUID
+}
+
+func (l *lossyID) UnmarshalText(data []byte) error {
+	if err := l.XUID.UnmarshalText(data); err != nil {
+		return err
+	}
+	l.SetPrefix("")
+	return nil
+}
+
+func (l *lossyID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return l.UnmarshalText([]byte(s))
+}
+
+func TestVerifyCodecConsistency(t *testing.T) {
+	t.Run("passes for XUIDs", func(t *testing.T) {
+		for _, x := range []xuid.XUID{xuid.MustNewSortable("user"), xuid.MustNewRandom(""), {}} {
+			xuidtest.VerifyCodecConsistency(t, x)
+		}
+	})
+
+	t.Run("passes for wrappers", func(t *testing.T) {
+		xuidtest.VerifyCodecConsistency(t, xuidorm.ID{XUID: xuid.MustNewSortable("user")})
+	})
+
+	t.Run("reports inconsistent codecs", func(t *testing.T) {
+		r := &recorder{TB: t}
+
+		xuidtest.VerifyCodecConsistency(r, lossyID{xuid.MustNewSortable("user")})
+
+		assert.Len(t, r.errors, 2)
+		assert.Contains(t, r.errors[0], "JSON round-trip")
+		assert.Contains(t, r.errors[1], "text round-trip")
+	})
+}
@@ -0,0 +1,98 @@
+package xuidtest
+
+import (
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+)
+
+// EdgePrefixes returns valid prefixes exercising corner cases of the string
+// format, simplest first.
+func EdgePrefixes() []string {
+	return []string{
+		"",
+		"a",
+		"user",
+		"user_profile",
+		"_",
+		"a__b",
+		"1",
+		"with space",
+		"test-prefix.with@chars",
+		"ユーザー",
+		strings.Repeat("p", xuid.MaxPrefixLen),
+	}
+}
+
+// BoundaryTimes returns timestamps at the edges of the range representable
+// by sortable XUIDs, earliest first.
+func BoundaryTimes() []time.Time {
+	return []time.Time{
+		time.UnixMilli(0),
+		time.UnixMilli(1),
+		time.UnixMilli(1<<31 - 1),
+		time.Unix(1<<31-1, 0), // 32-bit time_t overflow
+		time.UnixMilli(1<<47 - 1),
+		time.UnixMilli(1<<48 - 1), // largest 48-bit timestamp
+	}
+}
+
+// SortableFrom builds a sortable XUID from a timestamp and 10 bytes of
+// entropy, of which the version and variant bits leave 74. Only the lowest
+// 48 bits of the millisecond timestamp are kept.
+// Unlike xuid.NewSortableAt it is deterministic, which generators need.
+func SortableFrom(prefix string, t time.Time, entropy [10]byte) xuid.XUID {
+	var u uuid.UUID
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(t.UnixMilli()))
+	copy(u[:6], ms[2:])
+	copy(u[6:], entropy[:])
+	u[6] = (u[6] & 0x0f) | 0x70 // version 7
+	u[8] = (u[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return xuid.Must(xuid.NewWith(u, prefix))
+}
+
+// RandomFrom builds a random XUID from 16 bytes of entropy, of which the
+// version and variant bits leave 122.
+func RandomFrom(prefix string, entropy [16]byte) xuid.XUID {
+	u := uuid.UUID(entropy)
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return xuid.Must(xuid.NewWith(u, prefix))
+}
+
+// NumMutations is the number of mutation kinds understood by Mutate.
+const NumMutations = 8
+
+// Mutate applies mutation kind op, in [0, NumMutations), at byte position
+// pos of s. Positions wrap around the length of s. Mutations model common
+// corruptions of XUID strings; the result is usually, but not always,
+// invalid.
+func Mutate(s string, op, pos int) string {
+	if s == "" {
+		return s
+	}
+	pos %= len(s)
+	switch op % NumMutations {
+	case 0: // replace a character with one outside the alphabet
+		return s[:pos] + string("0OIl"[pos%4]) + s[pos+1:]
+	case 1: // truncate
+		return s[:pos]
+	case 2: // append a valid character
+		return s + "z"
+	case 3: // drop the separator
+		return strings.Replace(s, xuid.Separator, "", 1)
+	case 4: // duplicate the separator
+		return strings.Replace(s, xuid.Separator, xuid.Separator+xuid.Separator, 1)
+	case 5: // insert whitespace
+		return s[:pos] + " " + s[pos:]
+	case 6: // insert a zero-width space
+		return s[:pos] + "\u200b" + s[pos:]
+	default: // change the case of the payload
+		i := strings.LastIndex(s, xuid.Separator) + 1
+		return s[:i] + strings.ToUpper(s[i:])
+	}
+}
@@ -0,0 +1,81 @@
+package xuidtest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEdgePrefixes(t *testing.T) {
+	t.Run("round trip through the string form", func(t *testing.T) {
+		for _, prefix := range xuidtest.EdgePrefixes() {
+			id := xuid.Must(xuid.NewSortable(prefix))
+
+			parsed, err := xuid.Parse(id.String())
+
+			require.NoError(t, err, prefix)
+			assert.Equal(t, prefix, parsed.GetPrefix())
+		}
+	})
+}
+
+func TestBoundaryTimes(t *testing.T) {
+	t.Run("are embedded exactly", func(t *testing.T) {
+		for _, at := range xuidtest.BoundaryTimes() {
+			id := xuidtest.SortableFrom("", at, [10]byte{})
+
+			info, err := xuid.InspectString(id.String())
+
+			require.NoError(t, err)
+			assert.True(t, at.Equal(info.Time), at)
+		}
+	})
+}
+
+func TestFromEntropy(t *testing.T) {
+	t.Run("builds valid sortable XUIDs", func(t *testing.T) {
+		at := xuidtest.BoundaryTimes()[2]
+		id := xuidtest.SortableFrom("user", at, [10]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+
+		assert.True(t, id.IsSortable())
+		assert.Equal(t, "user", id.GetPrefix())
+		assert.True(t, id.Equal(xuidtest.SortableFrom("user", at, [10]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})))
+	})
+
+	t.Run("builds valid random XUIDs", func(t *testing.T) {
+		id := xuidtest.RandomFrom("user", [16]byte{1, 2, 3})
+
+		assert.True(t, id.IsRandom())
+		assert.Equal(t, "user", id.GetPrefix())
+	})
+}
+
+func TestMutate(t *testing.T) {
+	s := xuid.MustNewSortable("user").String()
+
+	t.Run("produces different strings", func(t *testing.T) {
+		for op := 0; op < xuidtest.NumMutations; op++ {
+			assert.NotEqual(t, s, xuidtest.Mutate(s, op, 7), op)
+		}
+	})
+
+	t.Run("wraps positions", func(t *testing.T) {
+		assert.Equal(t, xuidtest.Mutate(s, 0, 3), xuidtest.Mutate(s, 0, 3+len(s)))
+	})
+
+	t.Run("changes the case of the payload only", func(t *testing.T) {
+		prefix, payload, _ := strings.Cut(s, xuid.Separator)
+
+		got := xuidtest.Mutate(s, xuidtest.NumMutations-1, 0)
+
+		assert.Equal(t, prefix+xuid.Separator+strings.ToUpper(payload), got)
+	})
+
+	t.Run("leaves empty strings alone", func(t *testing.T) {
+		assert.Equal(t, "", xuidtest.Mutate("", 1, 3))
+	})
+}
@@ -0,0 +1,80 @@
+package xuidtest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/google/uuid"
+)
+
+// FuzzParseInput returns the corpus of adversarial strings this module
+// fuzzes Parse with. Seed fuzz tests of code accepting XUID strings with it
+// to start from the cases that broke, or nearly broke, Parse before.
+func FuzzParseInput() []string {
+	nilID, _ := xuid.NewWith(uuid.Nil, "")
+	maxID, _ := xuid.NewWith(uuid.Max, "")
+	valid := SortableFrom("user", BoundaryTimes()[2], [10]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	payload := maxID.String()
+
+	corpus := []string{
+		"",
+		xuid.Separator,
+		xuid.Separator + xuid.Separator,
+		"user" + xuid.Separator,
+		xuid.Separator + payload,
+		nilID.String(),
+		payload,
+		valid.String(),
+		"user_" + payload + "1",           // one character too long
+		"user_" + strings.Repeat("z", 22), // overflows 128 bits
+		"user_" + strings.Repeat("1", 17), // too many zero bytes
+		"user_" + payload[:xuid.EncodedLenMin-1],
+		"user_" + payload + xuid.Separator,
+		"user_0OIl" + payload[4:],
+		"user_" + payload[:10] + "\x00" + payload[10:],
+		"user_" + payload[:10] + "\u200b" + payload[10:],
+		"user_" + payload[:10] + "\xff" + payload[10:],
+		"user_" + payload[:10] + "\uff41" + payload[10:], // fullwidth letter
+		" user_" + payload + " ",
+		`"user_` + payload + `"`,
+		strings.Repeat("p", xuid.MaxPrefixLen+1) + "_" + payload,
+		strings.Repeat("_", xuid.MaxStringLen),
+		strings.Repeat("z", 4096),
+	}
+	for op := 0; op < NumMutations; op++ {
+		corpus = append(corpus, Mutate(valid.String(), op, len("user_")+3))
+	}
+	return corpus
+}
+
+// SeedParseCorpus adds FuzzParseInput to the seed corpus of f, whose fuzz
+// function must take a single string argument.
+func SeedParseCorpus(f *testing.F) {
+	for _, s := range FuzzParseInput() {
+		f.Add(s)
+	}
+}
+
+// CheckParse fails t if Parse violates its invariants on s: accepted
+// strings must describe a XUID whose string form parses back to an equal
+// XUID, and rejected strings must fail with an error wrapping ErrParse.
+func CheckParse(t testing.TB, s string) {
+	t.Helper()
+	id, err := xuid.Parse(s)
+	if err != nil {
+		if !errors.Is(err, xuid.ErrParse) {
+			t.Errorf("Parse(%q) failed with %v, which does not wrap ErrParse", s, err)
+		}
+		return
+	}
+	parsed, err := xuid.Parse(id.String())
+	if err != nil {
+		t.Errorf("Parse(%q) succeeded but its string form %q does not parse: %v", s, id.String(), err)
+		return
+	}
+	if !parsed.Equal(id) {
+		t.Errorf("Parse(%q) gave %q, which parses back to %q", s, id, parsed)
+	}
+}
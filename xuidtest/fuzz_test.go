@@ -0,0 +1,38 @@
+package xuidtest_test
+
+import (
+	"testing"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzParseInput(t *testing.T) {
+	t.Run("contains valid and invalid strings", func(t *testing.T) {
+		valid, invalid := 0, 0
+		for _, s := range xuidtest.FuzzParseInput() {
+			if xuid.IsValid(s) {
+				valid++
+			} else {
+				invalid++
+			}
+		}
+
+		assert.Greater(t, valid, 0)
+		assert.Greater(t, invalid, valid)
+	})
+
+	t.Run("satisfies Parse invariants", func(t *testing.T) {
+		for _, s := range xuidtest.FuzzParseInput() {
+			xuidtest.CheckParse(t, s)
+		}
+	})
+}
+
+func FuzzCheckParse(f *testing.F) {
+	xuidtest.SeedParseCorpus(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		xuidtest.CheckParse(t, s)
+	})
+}
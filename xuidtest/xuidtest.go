@@ -0,0 +1,113 @@
+// Package xuidtest provides helpers for verifying XUID integration in
+// application tests.
+//
+// For example, to check a Postgres driver and column type in CI:
+//
+//	func TestXUIDStorage(t *testing.T) {
+//		xuidtest.VerifyRoundTrip(t, "pgx", os.Getenv("TEST_POSTGRES_DSN"))
+//	}
+package xuidtest
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+)
+
+// ColumnType returns the column type VerifyRoundTrip uses for driverName.
+func ColumnType(driverName string) string {
+	switch driverName {
+	case "postgres", "pgx", "pgx/v5":
+		return "uuid"
+	case "mysql":
+		return "CHAR(36)"
+	case "godror", "oracle":
+		return "RAW(16)"
+	}
+	return "TEXT"
+}
+
+// VerifyRoundTrip checks that XUIDs stored with Value and loaded with Scan
+// through the given driver and database keep their UUID, and that empty
+// XUIDs are stored as NULL. It creates a temporary table with a column of
+// type ColumnType(driverName) and drops it afterwards.
+// The test is skipped when dsn is empty, so that the helper can be wired to
+// an environment variable that is only set in CI.
+//
+// RAW(16) columns used for Oracle require xuid.SQLStorageBinary to be set
+// in the package configuration before calling VerifyRoundTrip.
+func VerifyRoundTrip(t testing.TB, driverName, dsn string) {
+	t.Helper()
+	VerifyRoundTripColumn(t, driverName, dsn, ColumnType(driverName))
+}
+
+// VerifyRoundTripColumn is like VerifyRoundTrip but stores XUIDs in a
+// column of type columnType.
+func VerifyRoundTripColumn(t testing.TB, driverName, dsn, columnType string) {
+	t.Helper()
+	if dsn == "" {
+		t.Skip("xuidtest: no DSN configured for " + driverName)
+	}
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		t.Fatalf("xuidtest: open %s: %v", driverName, err)
+	}
+	defer db.Close()
+
+	table := fmt.Sprintf("xuidtest_%d", time.Now().UnixNano())
+	if _, err := db.Exec(fmt.Sprintf("CREATE TABLE %s (k INTEGER PRIMARY KEY, v %s NULL)", table, columnType)); err != nil {
+		t.Fatalf("xuidtest: create table: %v", err)
+	}
+	defer func() {
+		if _, err := db.Exec("DROP TABLE " + table); err != nil {
+			t.Errorf("xuidtest: drop table: %v", err)
+		}
+	}()
+
+	insert := fmt.Sprintf("INSERT INTO %s (k, v) VALUES (%s, %s)", table, placeholder(driverName, 1), placeholder(driverName, 2))
+	query := fmt.Sprintf("SELECT v FROM %s WHERE k = %s", table, placeholder(driverName, 1))
+
+	nilID, _ := xuid.NilUUID()
+	cases := []xuid.XUID{
+		xuid.MustNewSortable("xuidtest"),
+		xuid.MustNewRandom(""),
+		nilID,
+	}
+	for k, want := range cases {
+		if _, err := db.Exec(insert, k, want); err != nil {
+			t.Errorf("xuidtest: insert %s: %v", want, err)
+			continue
+		}
+		var got xuid.XUID
+		if err := db.QueryRow(query, k).Scan(&got); err != nil {
+			t.Errorf("xuidtest: scan %s: %v", want, err)
+			continue
+		}
+		if got.GetUUID() != want.GetUUID() {
+			t.Errorf("xuidtest: stored %s, loaded %s", want.GetUUID(), got.GetUUID())
+		}
+
+		var isNull bool
+		var raw any
+		if err := db.QueryRow(query, k).Scan(&raw); err == nil {
+			isNull = raw == nil
+		}
+		if xuid.IsEmpty(want) != isNull {
+			t.Errorf("xuidtest: empty XUID must be stored as NULL and others as values, %s stored as %v", want.GetUUID(), raw)
+		}
+	}
+}
+
+func placeholder(driverName string, n int) string {
+	if strings.HasPrefix(driverName, "postgres") || strings.HasPrefix(driverName, "pgx") {
+		return fmt.Sprintf("$%d", n)
+	}
+	if driverName == "godror" || driverName == "oracle" {
+		return fmt.Sprintf(":%d", n)
+	}
+	return "?"
+}
@@ -0,0 +1,102 @@
+package xuidtest_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/47monad/xuid/xuidtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// memDriver is a database/sql driver understanding just enough SQL for
+// VerifyRoundTrip: CREATE and DROP are ignored, INSERT stores its second
+// argument under its first and SELECT returns the value stored under its
+// argument.
+type memDriver struct {
+	mu   sync.Mutex
+	rows map[int64]driver.Value
+}
+
+func (d *memDriver) Open(string) (driver.Conn, error) { return memConn{d}, nil }
+
+type memConn struct{ d *memDriver }
+
+func (c memConn) Prepare(query string) (driver.Stmt, error) { return memStmt{c.d, query}, nil }
+func (memConn) Close() error                                { return nil }
+func (memConn) Begin() (driver.Tx, error)                   { return nil, errors.New("not supported") }
+
+type memStmt struct {
+	d     *memDriver
+	query string
+}
+
+func (memStmt) Close() error  { return nil }
+func (memStmt) NumInput() int { return -1 }
+
+func (s memStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if strings.HasPrefix(s.query, "INSERT") {
+		s.d.mu.Lock()
+		s.d.rows[args[0].(int64)] = args[1]
+		s.d.mu.Unlock()
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s memStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	v, ok := s.d.rows[args[0].(int64)]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &memRows{value: v}, nil
+}
+
+type memRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (*memRows) Columns() []string { return []string{"v"} }
+func (*memRows) Close() error      { return nil }
+
+func (r *memRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+func init() {
+	sql.Register("xuidtest-mem", &memDriver{rows: map[int64]driver.Value{}})
+}
+
+func TestVerifyRoundTrip(t *testing.T) {
+	t.Run("passes against a driver storing values", func(t *testing.T) {
+		xuidtest.VerifyRoundTrip(t, "xuidtest-mem", "mem")
+	})
+
+	t.Run("skips without DSN", func(t *testing.T) {
+		passed := t.Run("inner", func(t *testing.T) {
+			xuidtest.VerifyRoundTrip(t, "xuidtest-mem", "")
+			t.Error("must not be reached")
+		})
+
+		assert.True(t, passed)
+	})
+}
+
+func TestColumnType(t *testing.T) {
+	assert.Equal(t, "uuid", xuidtest.ColumnType("pgx"))
+	assert.Equal(t, "uuid", xuidtest.ColumnType("postgres"))
+	assert.Equal(t, "CHAR(36)", xuidtest.ColumnType("mysql"))
+	assert.Equal(t, "RAW(16)", xuidtest.ColumnType("godror"))
+	assert.Equal(t, "TEXT", xuidtest.ColumnType("sqlite3"))
+}
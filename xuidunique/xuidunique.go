@@ -0,0 +1,116 @@
+// Package xuidunique detects duplicate XUIDs across a fleet by registering
+// every generated UUID in Redis for a limited time. It is meant for canary
+// periods, such as when rolling out a custom entropy source or UUIDv8
+// layout, not for guaranteeing uniqueness in production.
+//
+// Redis is accessed through the Store interface, which go-redis clients
+// satisfy with a small adapter:
+//
+//	type store struct{ *redis.Client }
+//
+//	func (s store) SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error) {
+//		return s.Client.SetNX(ctx, key, value, ttl).Result()
+//	}
+//
+//	func (s store) Get(ctx context.Context, key string) (string, error) {
+//		return s.Client.Get(ctx, key).Result()
+//	}
+package xuidunique
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/47monad/xuid"
+)
+
+// KeyPrefix starts the Redis keys of registered UUIDs.
+const KeyPrefix = "xuidunique:"
+
+var ErrDuplicate = errors.New("XUID was already registered")
+
+// Store sets and reads Redis string keys.
+type Store interface {
+	// SetNX sets key to value with a TTL if key does not exist, and reports
+	// whether it was set.
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+	// Get returns the value of key.
+	Get(ctx context.Context, key string) (string, error)
+}
+
+// Duplicate describes a UUID registered twice within the TTL.
+type Duplicate struct {
+	// XUID is the XUID being registered.
+	XUID xuid.XUID
+	// Node is the node registering it and Holder the node that registered
+	// it first, or "" if the holder could not be read. Equal nodes point
+	// at a generator bug, different nodes at a collision.
+	Node   string
+	Holder string
+}
+
+// Checker registers XUIDs in a Store.
+type Checker struct {
+	store       Store
+	node        string
+	ttl         time.Duration
+	onDuplicate func(Duplicate)
+	onError     func(error)
+}
+
+// New returns a Checker registering UUIDs in store for ttl, on behalf of
+// node, such as the host name, which is reported in duplicates.
+func New(store Store, node string, ttl time.Duration) *Checker {
+	return &Checker{store: store, node: node, ttl: ttl}
+}
+
+// OnDuplicate sets a function called with every duplicate found.
+func (c *Checker) OnDuplicate(f func(Duplicate)) *Checker {
+	c.onDuplicate = f
+	return c
+}
+
+// OnError sets a function called with the store errors ignored by Wrap.
+func (c *Checker) OnError(f func(error)) *Checker {
+	c.onError = f
+	return c
+}
+
+// Check registers the UUID of x. It fails with ErrDuplicate, after calling
+// the OnDuplicate function, if the UUID is already registered, whatever
+// the prefix it was registered with.
+func (c *Checker) Check(ctx context.Context, x xuid.XUID) error {
+	key := KeyPrefix + x.GetUUID().String()
+	ok, err := c.store.SetNX(ctx, key, c.node, c.ttl)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+	dup := Duplicate{XUID: x, Node: c.node}
+	dup.Holder, _ = c.store.Get(ctx, key)
+	if c.onDuplicate != nil {
+		c.onDuplicate(dup)
+	}
+	return fmt.Errorf("%w: %s, first registered by %q", ErrDuplicate, x, dup.Holder)
+}
+
+// Wrap returns a generator calling gen and registering the XUIDs it
+// returns. Duplicates are only reported to the OnDuplicate function and
+// store errors to the OnError function, so the wrapped generator fails
+// only when gen does and Redis outages do not affect callers.
+func (c *Checker) Wrap(ctx context.Context, gen func() (xuid.XUID, error)) func() (xuid.XUID, error) {
+	return func() (xuid.XUID, error) {
+		x, err := gen()
+		if err != nil {
+			return x, err
+		}
+		if err := c.Check(ctx, x); err != nil && !errors.Is(err, ErrDuplicate) && c.onError != nil {
+			c.onError(err)
+		}
+		return x, nil
+	}
+}
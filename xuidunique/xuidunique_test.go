@@ -0,0 +1,123 @@
+package xuidunique_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/47monad/xuid"
+	"github.com/47monad/xuid/xuidunique"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore is an in-memory Store that ignores TTLs.
+type memStore struct {
+	mu   sync.Mutex
+	keys map[string]string
+	ttls map[string]time.Duration
+	err  error
+}
+
+func newMemStore() *memStore {
+	return &memStore{keys: map[string]string{}, ttls: map[string]time.Duration{}}
+}
+
+func (s *memStore) SetNX(_ context.Context, key, value string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return false, s.err
+	}
+	if _, ok := s.keys[key]; ok {
+		return false, nil
+	}
+	s.keys[key], s.ttls[key] = value, ttl
+	return true, nil
+}
+
+func (s *memStore) Get(_ context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys[key], nil
+}
+
+func TestChecker(t *testing.T) {
+	ctx := context.Background()
+	id := xuid.MustNewSortable("user")
+
+	t.Run("registers UUIDs with a TTL", func(t *testing.T) {
+		store := newMemStore()
+		c := xuidunique.New(store, "node-1", time.Hour)
+
+		require.NoError(t, c.Check(ctx, id))
+
+		key := xuidunique.KeyPrefix + id.GetUUID().String()
+		assert.Equal(t, "node-1", store.keys[key])
+		assert.Equal(t, time.Hour, store.ttls[key])
+	})
+
+	t.Run("reports duplicates across nodes and prefixes", func(t *testing.T) {
+		store := newMemStore()
+		var dups []xuidunique.Duplicate
+		c1 := xuidunique.New(store, "node-1", time.Hour)
+		c2 := xuidunique.New(store, "node-2", time.Hour).OnDuplicate(func(d xuidunique.Duplicate) { dups = append(dups, d) })
+		other := xuid.Must(xuid.NewWith(id.GetUUID(), "org"))
+		require.NoError(t, c1.Check(ctx, id))
+
+		err := c2.Check(ctx, other)
+
+		assert.ErrorIs(t, err, xuidunique.ErrDuplicate)
+		assert.Equal(t, []xuidunique.Duplicate{{XUID: other, Node: "node-2", Holder: "node-1"}}, dups)
+	})
+
+	t.Run("returns store errors", func(t *testing.T) {
+		store := newMemStore()
+		store.err = errors.New("connection refused")
+
+		assert.ErrorIs(t, xuidunique.New(store, "node-1", time.Hour).Check(ctx, id), store.err)
+	})
+}
+
+func TestCheckerWrap(t *testing.T) {
+	ctx := context.Background()
+	fixed := xuid.Must(xuid.NewWith(uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057"), "user"))
+
+	t.Run("reports duplicates without failing", func(t *testing.T) {
+		dups := 0
+		c := xuidunique.New(newMemStore(), "node-1", time.Hour).OnDuplicate(func(xuidunique.Duplicate) { dups++ })
+		gen := c.Wrap(ctx, func() (xuid.XUID, error) { return fixed, nil })
+
+		for i := 0; i < 3; i++ {
+			x, err := gen()
+			require.NoError(t, err)
+			assert.Equal(t, fixed, x)
+		}
+		assert.Equal(t, 2, dups)
+	})
+
+	t.Run("reports store errors without failing", func(t *testing.T) {
+		store := newMemStore()
+		store.err = errors.New("connection refused")
+		var errs []error
+		c := xuidunique.New(store, "node-1", time.Hour).OnError(func(err error) { errs = append(errs, err) })
+
+		x, err := c.Wrap(ctx, func() (xuid.XUID, error) { return fixed, nil })()
+
+		require.NoError(t, err)
+		assert.Equal(t, fixed, x)
+		assert.Equal(t, []error{store.err}, errs)
+	})
+
+	t.Run("returns generator errors", func(t *testing.T) {
+		genErr := errors.New("entropy exhausted")
+		c := xuidunique.New(newMemStore(), "node-1", time.Hour)
+
+		_, err := c.Wrap(ctx, func() (xuid.XUID, error) { return xuid.XUID{}, genErr })()
+
+		assert.ErrorIs(t, err, genErr)
+	})
+}